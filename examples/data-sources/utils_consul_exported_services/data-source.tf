@@ -0,0 +1,5 @@
+data "utils_consul_exported_services" "example" {}
+
+output "services_exported_to_other_cluster" {
+  value = lookup(data.utils_consul_exported_services.example.services_by_peer, "other-cluster", [])
+}
@@ -0,0 +1,11 @@
+data "utils_consul_intention_check" "example" {
+  source_service      = "source"
+  destination_service = "destination"
+
+  lifecycle {
+    postcondition {
+      condition     = self.allowed
+      error_message = "source is not allowed to reach destination."
+    }
+  }
+}
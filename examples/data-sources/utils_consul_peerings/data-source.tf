@@ -0,0 +1,12 @@
+data "utils_consul_peerings" "example" {}
+
+# Export the logging service to every active peer
+resource "utils_consul_exported_service" "logging" {
+  for_each = {
+    for name, peering in data.utils_consul_peerings.example.by_name : name => peering
+    if peering.state == "ACTIVE"
+  }
+
+  peer_name         = each.key
+  service_to_export = "logging-service"
+}
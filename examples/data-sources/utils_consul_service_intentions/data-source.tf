@@ -0,0 +1,10 @@
+data "utils_consul_service_intentions" "example" {
+  destination_service = "destination"
+}
+
+output "allowed_sources" {
+  value = [
+    for source in data.utils_consul_service_intentions.example.sources : source.name
+    if source.action == "allow"
+  ]
+}
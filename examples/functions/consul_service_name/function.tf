@@ -0,0 +1,5 @@
+resource "utils_consul_service_defaults_field" "protocol" {
+  # "billing-api" for the "Billing_API" application
+  service  = provider::utils::consul_service_name(var.application_name)
+  protocol = "http"
+}
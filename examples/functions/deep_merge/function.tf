@@ -0,0 +1,16 @@
+locals {
+  # { Name = "web", Meta = { team = "platform", tier = "frontend" },
+  #   Routes = [{ name = "api", retries = 3 }, { name = "admin" }] }
+  service = provider::utils::deep_merge(
+    "union:name",
+    {
+      Name   = "web"
+      Meta   = { team = "platform" }
+      Routes = [{ name = "api", retries = 1 }, { name = "admin" }]
+    },
+    {
+      Meta   = { tier = "frontend" }
+      Routes = [{ name = "api", retries = 3 }]
+    },
+  )
+}
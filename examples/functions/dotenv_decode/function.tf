@@ -0,0 +1,8 @@
+locals {
+  environment = provider::utils::dotenv_decode(file("${path.module}/.env"))
+}
+
+output "database_url" {
+  value     = local.environment["DATABASE_URL"]
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+resource "utils_consul_key" "environment" {
+  path = "config/web/.env"
+
+  value = provider::utils::dotenv_encode({
+    LOG_LEVEL    = "info"
+    DATABASE_URL = "postgres://db.service.consul:5432/web"
+  })
+}
@@ -0,0 +1,7 @@
+locals {
+  settings = provider::utils::ini_decode(file("${path.module}/settings.ini"))
+}
+
+output "database_host" {
+  value = local.settings["database"]["host"]
+}
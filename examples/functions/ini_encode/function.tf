@@ -0,0 +1,15 @@
+resource "utils_consul_key" "settings" {
+  path = "config/web/settings.ini"
+
+  # The "" section holds the keys written before any section header
+  value = provider::utils::ini_encode({
+    "" = {
+      environment = "production"
+    }
+
+    database = {
+      host = "db.service.consul"
+      port = "5432"
+    }
+  })
+}
@@ -0,0 +1,4 @@
+import {
+  to = utils_consul_single_intention.web_to_api
+  id = provider::utils::intention_id("api", "web", null)
+}
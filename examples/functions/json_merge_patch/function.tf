@@ -0,0 +1,12 @@
+resource "utils_consul_key" "settings" {
+  path = "config/web/settings"
+
+  # Overrides the defaults shared by every environment
+  value = provider::utils::json_merge_patch(
+    file("${path.module}/defaults.json"),
+    jsonencode({
+      log_level = "debug"
+      tracing   = null
+    }),
+  )
+}
@@ -0,0 +1,9 @@
+locals {
+  router = provider::utils::json_patch(
+    file("${path.module}/router.json"),
+    jsonencode([
+      { op = "test", path = "/Routes/0/Destination/Service", value = "web" },
+      { op = "add", path = "/Routes/0/Destination/NumRetries", value = 3 },
+    ]),
+  )
+}
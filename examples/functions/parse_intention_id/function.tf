@@ -0,0 +1,8 @@
+locals {
+  # { destination = "api", source = "web", peer = "other-cluster" }
+  intention = provider::utils::parse_intention_id("api_web_other-cluster")
+}
+
+output "intention_source" {
+  value = local.intention.source
+}
@@ -0,0 +1,7 @@
+locals {
+  config = provider::utils::toml_decode(file("${path.module}/config.toml"))
+}
+
+output "port" {
+  value = local.config.server.port
+}
@@ -0,0 +1,12 @@
+resource "utils_consul_key" "config" {
+  path = "config/web/config.toml"
+
+  value = provider::utils::toml_encode({
+    log_level = "info"
+
+    server = {
+      host = "0.0.0.0"
+      port = 8080
+    }
+  })
+}
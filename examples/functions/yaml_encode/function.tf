@@ -0,0 +1,13 @@
+resource "utils_consul_key" "config" {
+  path = "config/web/config.yaml"
+
+  # The keys are sorted so the value only changes with the configuration
+  value = provider::utils::yaml_encode({
+    server = {
+      port = 8080
+      host = "0.0.0.0"
+    }
+
+    features = ["tracing", "metrics"]
+  })
+}
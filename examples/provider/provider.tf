@@ -4,3 +4,18 @@ provider "utils" {
   # Example token
   consul_token = "4e24aacc-7d60-4050-913b-2ad101cbae0c"
 }
+
+# Resources and data sources setting cluster = "secondary" manage this cluster
+provider "utils" {
+  alias = "multi_cluster"
+
+  consul_cluster_address = "https://primary.example.com"
+  consul_token_file      = "/var/run/secrets/consul/primary-token"
+
+  cluster {
+    name                   = "secondary"
+    consul_cluster_address = "https://secondary.example.com"
+    consul_token_file      = "/var/run/secrets/consul/secondary-token"
+    ca_file                = "/etc/consul/secondary-ca.pem"
+  }
+}
@@ -0,0 +1,4 @@
+# The ID is the name of the auth method. The OIDC client secret and the
+# kubernetes service account JWT are not returned by consul, and are set by
+# the next apply
+terraform import utils_consul_acl_auth_method.kubernetes kubernetes
@@ -0,0 +1,28 @@
+resource "utils_consul_acl_auth_method" "ci" {
+  name          = "ci"
+  type          = "jwt"
+  max_token_ttl = "1h"
+
+  jwt = {
+    jwks_url          = "https://gitlab.example.com/oauth/discovery/keys"
+    bound_issuer      = "https://gitlab.example.com"
+    bound_audiences   = ["consul"]
+    clock_skew_leeway = "30s"
+
+    claim_mappings = {
+      project_path = "project_path"
+      ref          = "ref"
+    }
+  }
+}
+
+resource "utils_consul_acl_auth_method" "kubernetes" {
+  name = "kubernetes"
+  type = "kubernetes"
+
+  kubernetes = {
+    host                = "https://kubernetes.default.svc"
+    ca_cert             = file("ca.crt")
+    service_account_jwt = var.service_account_jwt
+  }
+}
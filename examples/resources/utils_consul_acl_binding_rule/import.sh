@@ -0,0 +1,2 @@
+# The ID is the UUID of the binding rule
+terraform import utils_consul_acl_binding_rule.services 8f246b77-f3e1-ff88-5b48-8ec93abf3e05
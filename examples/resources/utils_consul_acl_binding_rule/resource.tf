@@ -0,0 +1,7 @@
+resource "utils_consul_acl_binding_rule" "deploy" {
+  auth_method = utils_consul_acl_auth_method.ci.name
+  description = "Deployments of the main branches"
+  selector    = "value.ref == \"main\""
+  bind_type   = "role"
+  bind_name   = "deploy"
+}
@@ -0,0 +1,4 @@
+# The ID is made of the policy and the resource, followed by the segment for
+# the resources whose rules are labeled
+terraform import utils_consul_acl_policy_rule.web utils-test_service_web
+terraform import utils_consul_acl_policy_rule.operator utils-test_operator
@@ -0,0 +1,7 @@
+resource "utils_consul_acl_policy_rule" "web" {
+  policy     = "platform-services"
+  resource   = "service"
+  segment    = "web"
+  access     = "write"
+  intentions = "read"
+}
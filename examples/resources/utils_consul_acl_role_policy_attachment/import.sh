@@ -0,0 +1,2 @@
+# The ID is made of the role and the policy
+terraform import utils_consul_acl_role_policy_attachment.web web-role_web-policy
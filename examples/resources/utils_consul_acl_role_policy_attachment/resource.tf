@@ -0,0 +1,4 @@
+resource "utils_consul_acl_role_policy_attachment" "web" {
+  role   = "platform-services"
+  policy = "web-service"
+}
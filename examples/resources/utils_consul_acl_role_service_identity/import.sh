@@ -0,0 +1,2 @@
+# The ID is made of the role and the service of the identity
+terraform import utils_consul_acl_role_service_identity.web web-role_web
@@ -0,0 +1,5 @@
+resource "utils_consul_acl_role_service_identity" "web" {
+  role         = "platform-services"
+  service_name = "web"
+  datacenters  = ["dc1"]
+}
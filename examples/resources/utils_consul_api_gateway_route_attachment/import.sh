@@ -0,0 +1,5 @@
+# The ID is made of the route and the gateway, followed by the listener when
+# the route is attached to a single one. The ID of a TCP route is prefixed by
+# its kind
+terraform import utils_consul_api_gateway_route_attachment.web web_api-gateway_http
+terraform import utils_consul_api_gateway_route_attachment.db tcp-route_db_api-gateway
@@ -0,0 +1,5 @@
+resource "utils_consul_api_gateway_route_attachment" "web" {
+  route    = "web"
+  gateway  = "api-gateway"
+  listener = "http"
+}
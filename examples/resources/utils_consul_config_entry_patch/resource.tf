@@ -0,0 +1,18 @@
+# Set the protocol of the service, keeping the other fields of the entry
+resource "utils_consul_config_entry_patch" "protocol" {
+  kind  = "service-defaults"
+  name  = "web"
+  patch = jsonencode({ Protocol = "http" })
+}
+
+# Append an upstream override to the ones of the other teams
+resource "utils_consul_config_entry_patch" "upstream" {
+  kind       = "service-defaults"
+  name       = "web"
+  patch_type = "json"
+  patch = jsonencode([{
+    op    = "add"
+    path  = "/UpstreamConfig/Overrides/-"
+    value = { Name = "billing", ConnectTimeoutMs = 5000 }
+  }])
+}
@@ -2,3 +2,28 @@ resource "utils_consul_exported_service" "example" {
   peer_name         = "other-cluster"
   service_to_export = "logging-service"
 }
+
+resource "utils_consul_exported_service" "wait_for_peer" {
+  peer_name         = "other-cluster"
+  service_to_export = "logging-service"
+
+  wait_for_active         = true
+  wait_for_active_timeout = "10m"
+}
+
+# Let billing, on the top-level cluster, call notifications on the cluster of
+# the provider block named "secondary", through their peering
+resource "utils_consul_exported_service" "notifications" {
+  cluster = "secondary"
+
+  peer_name         = "primary-cluster"
+  service_to_export = "notifications"
+}
+
+resource "utils_consul_single_intention" "billing_to_notifications" {
+  cluster = "secondary"
+
+  destination_service = "notifications"
+  source_service      = "billing"
+  source_peer         = "primary-cluster"
+}
@@ -0,0 +1,14 @@
+resource "utils_consul_http_route_rule" "billing" {
+  route = "web"
+
+  match = [{
+    path_match = "prefix"
+    path       = "/billing"
+  }]
+
+  service = [{
+    name = "billing"
+  }]
+
+  url_rewrite_path = "/"
+}
@@ -0,0 +1,3 @@
+# The ID is made of the gateway, the port of the listener and the service,
+# followed by the namespace and the partition of the service when set
+terraform import utils_consul_ingress_gateway_service.web ingress_8080_web
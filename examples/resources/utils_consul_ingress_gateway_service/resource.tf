@@ -0,0 +1,7 @@
+resource "utils_consul_ingress_gateway_service" "web" {
+  gateway  = "ingress"
+  port     = 8080
+  protocol = "http"
+  service  = "web"
+  hosts    = ["web.example.com"]
+}
@@ -0,0 +1,3 @@
+# The ID is made of the service and the field, service_protocol or
+# service_max_inbound_connections
+terraform import utils_consul_service_defaults_field.protocol web_protocol
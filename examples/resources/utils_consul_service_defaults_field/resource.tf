@@ -0,0 +1,9 @@
+resource "utils_consul_service_defaults_field" "protocol" {
+  service  = "web"
+  protocol = "http"
+}
+
+resource "utils_consul_service_defaults_field" "max_inbound_connections" {
+  service                 = "web"
+  max_inbound_connections = 512
+}
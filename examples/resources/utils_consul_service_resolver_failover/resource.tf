@@ -0,0 +1,16 @@
+# Each region contributes its own failover target to the same resolver
+resource "utils_consul_service_resolver_failover" "eu_west" {
+  resolver = "web"
+  peer     = "eu-west"
+}
+
+resource "utils_consul_service_resolver_failover" "us_east" {
+  resolver   = "web"
+  datacenter = "us-east-1"
+}
+
+resource "utils_consul_service_resolver_failover" "v2" {
+  resolver        = "web"
+  failover_subset = "v2"
+  sameness_group  = "web-everywhere"
+}
@@ -0,0 +1,2 @@
+# The ID is made of the resolver and the name of the subset
+terraform import utils_consul_service_resolver_subset.v2 web_v2
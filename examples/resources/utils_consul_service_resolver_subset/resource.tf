@@ -0,0 +1,6 @@
+resource "utils_consul_service_resolver_subset" "v2" {
+  resolver     = "web"
+  name         = "v2"
+  filter       = "Service.Meta.version == v2"
+  only_passing = true
+}
@@ -0,0 +1,42 @@
+resource "utils_consul_service_router_route" "admin" {
+  router   = "web"
+  priority = 10
+
+  match = {
+    path_prefix = "/admin"
+    methods     = ["GET", "POST"]
+
+    header = [
+      {
+        name  = "x-debug"
+        exact = "1"
+      },
+    ]
+  }
+
+  destination = {
+    service                  = "admin"
+    prefix_rewrite           = "/"
+    request_timeout          = "10s"
+    num_retries              = 3
+    retry_on_connect_failure = true
+    retry_on_status_codes    = [503]
+  }
+}
+
+resource "utils_consul_service_router_route" "canary" {
+  router = "web"
+
+  match = {
+    query_param = [
+      {
+        name  = "canary"
+        exact = "true"
+      },
+    ]
+  }
+
+  destination = {
+    service_subset = "canary"
+  }
+}
@@ -0,0 +1,3 @@
+# The ID is made of the splitter and the service, followed by the subset of
+# the service when the split targets one
+terraform import utils_consul_service_splitter_split.canary web_web_v2
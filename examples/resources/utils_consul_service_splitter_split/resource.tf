@@ -0,0 +1,15 @@
+# Shifting weights between the two splits in one apply writes them together,
+# so the weights of the entry always sum to 100.
+resource "utils_consul_service_splitter_split" "stable" {
+  splitter       = "web"
+  service        = "web"
+  service_subset = "v1"
+  weight         = 90
+}
+
+resource "utils_consul_service_splitter_split" "canary" {
+  splitter       = "web"
+  service        = "web"
+  service_subset = "v2"
+  weight         = 10
+}
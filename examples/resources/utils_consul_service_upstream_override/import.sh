@@ -0,0 +1,3 @@
+# The ID is made of the service and the upstream, followed by the peer of
+# the upstream when it is imported from one
+terraform import utils_consul_service_upstream_override.example web_db_other-cluster
@@ -0,0 +1,19 @@
+resource "utils_consul_service_upstream_override" "example" {
+  service            = "web"
+  upstream           = "db"
+  peer               = "other-cluster"
+  protocol           = "tcp"
+  connect_timeout_ms = 2000
+
+  limits = {
+    max_connections      = 100
+    max_pending_requests = 50
+  }
+
+  passive_health_check = {
+    interval             = "10s"
+    max_failures         = 5
+    max_ejection_percent = 50
+    base_ejection_time   = "30s"
+  }
+}
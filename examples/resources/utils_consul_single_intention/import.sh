@@ -0,0 +1,5 @@
+# The ID is made of the destination and the source services, followed by the
+# peer of the source when it is imported from one. It can be built with the
+# provider::utils::intention_id function
+terraform import utils_consul_single_intention.web web_api
+terraform import utils_consul_single_intention.peered web_api_other-cluster
@@ -3,3 +3,12 @@ resource "utils_consul_single_intention" "example" {
   source_service      = "source"
   source_peer         = "other-cluster"
 }
+
+resource "utils_consul_single_intention" "wait_for_peer" {
+  destination_service = "destination"
+  source_service      = "source"
+  source_peer         = "other-cluster"
+
+  wait_for_active         = true
+  wait_for_active_timeout = "10m"
+}
@@ -0,0 +1,3 @@
+# The ID is made of the gateway and the service, followed by the namespace
+# of the service when set
+terraform import utils_consul_terminating_gateway_service.billing terminating_billing
@@ -0,0 +1,6 @@
+resource "utils_consul_terminating_gateway_service" "billing" {
+  gateway = "terminating"
+  service = "billing"
+  ca_file = "/etc/ssl/billing-ca.pem"
+  sni     = "billing.example.com"
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	api "github.com/hashicorp/consul/api"
+)
+
+var aclObjectMutexes map[string]*sync.Mutex
+var aclObjectMutexesLock sync.Mutex
+
+// getMutexForACLObject returns the mutex serializing the modifications of an
+// ACL policy, role or token made by this provider, since consul offers no
+// CAS writes for them. The client tells apart the objects of each cluster.
+func getMutexForACLObject(client *api.Client, kind, id string) *sync.Mutex {
+	aclObjectMutexesLock.Lock()
+	defer aclObjectMutexesLock.Unlock()
+
+	if aclObjectMutexes == nil {
+		aclObjectMutexes = make(map[string]*sync.Mutex)
+	}
+
+	key := fmt.Sprintf("%p/%s/%s", client, kind, id)
+
+	if _, ok := aclObjectMutexes[key]; !ok {
+		aclObjectMutexes[key] = &sync.Mutex{}
+	}
+
+	return aclObjectMutexes[key]
+}
+
+// readACLPolicy returns the policy with the ID or the name nameOrID, or nil when it does not exist.
+func readACLPolicy(client *api.Client, nameOrID string) (*api.ACLPolicy, error) {
+	var policy *api.ACLPolicy
+	var err error
+
+	if _, parseErr := uuid.Parse(nameOrID); parseErr == nil {
+		policy, _, err = client.ACL().PolicyRead(nameOrID, nil)
+	} else {
+		policy, _, err = client.ACL().PolicyReadByName(nameOrID, nil)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ACL policy %q: %w", nameOrID, err)
+	}
+
+	return policy, nil
+}
+
+// updateACLPolicyRules applies update to the rules of the policy with the ID or the name nameOrID.
+func updateACLPolicyRules(client *api.Client, nameOrID string, update func(rules string) (string, error)) error {
+	policy, err := readACLPolicy(client, nameOrID)
+
+	if err != nil {
+		return err
+	}
+
+	if policy == nil {
+		return fmt.Errorf("ACL policy %q does not exist", nameOrID)
+	}
+
+	policyMutex := getMutexForACLObject(client, "policy", policy.ID)
+
+	policyMutex.Lock()
+	defer policyMutex.Unlock()
+
+	// The policy may have been modified while waiting for the mutex
+	policy, err = readACLPolicy(client, policy.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if policy == nil {
+		return fmt.Errorf("ACL policy %q does not exist", nameOrID)
+	}
+
+	rules, err := update(policy.Rules)
+
+	if err != nil {
+		return err
+	}
+
+	policy.Rules = rules
+
+	if _, _, err := client.ACL().PolicyUpdate(policy, nil); err != nil {
+		return fmt.Errorf("unable to update ACL policy %q: %w", nameOrID, err)
+	}
+
+	return nil
+}
+
+// readACLRole returns the role with the ID or the name nameOrID, or nil when it does not exist.
+func readACLRole(client *api.Client, nameOrID string) (*api.ACLRole, error) {
+	var role *api.ACLRole
+	var err error
+
+	if _, parseErr := uuid.Parse(nameOrID); parseErr == nil {
+		role, _, err = client.ACL().RoleRead(nameOrID, nil)
+	} else {
+		role, _, err = client.ACL().RoleReadByName(nameOrID, nil)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ACL role %q: %w", nameOrID, err)
+	}
+
+	return role, nil
+}
+
+// updateACLRole applies update to the role with the ID or the name nameOrID.
+func updateACLRole(client *api.Client, nameOrID string, update func(role *api.ACLRole) error) error {
+	role, err := readACLRole(client, nameOrID)
+
+	if err != nil {
+		return err
+	}
+
+	if role == nil {
+		return fmt.Errorf("ACL role %q does not exist", nameOrID)
+	}
+
+	roleMutex := getMutexForACLObject(client, "role", role.ID)
+
+	roleMutex.Lock()
+	defer roleMutex.Unlock()
+
+	// The role may have been modified while waiting for the mutex
+	role, err = readACLRole(client, role.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if role == nil {
+		return fmt.Errorf("ACL role %q does not exist", nameOrID)
+	}
+
+	if err := update(role); err != nil {
+		return err
+	}
+
+	if _, _, err := client.ACL().RoleUpdate(role, nil); err != nil {
+		return fmt.Errorf("unable to update ACL role %q: %w", nameOrID, err)
+	}
+
+	return nil
+}
+
+// findRolePolicy returns the index of the link to the policy policyID, or -1 when there is none.
+func findRolePolicy(role *api.ACLRole, policyID string) int {
+	for i, link := range role.Policies {
+		if link.ID == policyID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findRoleServiceIdentity returns the index of the identity of the service, or -1 when there is none.
+func findRoleServiceIdentity(role *api.ACLRole, serviceName string) int {
+	for i, identity := range role.ServiceIdentities {
+		if identity.ServiceName == serviceName {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,809 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The types of auth methods managed by the resource.
+const (
+	jwtAuthMethodType        = "jwt"
+	oidcAuthMethodType       = "oidc"
+	kubernetesAuthMethodType = "kubernetes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulACLAuthMethodResource{}
+var _ resource.ResourceWithImportState = &ConsulACLAuthMethodResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulACLAuthMethodResource{}
+
+func NewConsulACLAuthMethodResource() resource.Resource {
+	return &ConsulACLAuthMethodResource{}
+}
+
+// ConsulACLAuthMethodResource defines the resource implementation.
+type ConsulACLAuthMethodResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulACLAuthMethodResourceModel describes the resource data model.
+type ConsulACLAuthMethodResourceModel struct {
+	Cluster       types.String                        `tfsdk:"cluster"`
+	Name          types.String                        `tfsdk:"name"`
+	Type          types.String                        `tfsdk:"type"`
+	DisplayName   types.String                        `tfsdk:"display_name"`
+	Description   types.String                        `tfsdk:"description"`
+	MaxTokenTTL   types.String                        `tfsdk:"max_token_ttl"`
+	TokenLocality types.String                        `tfsdk:"token_locality"`
+	JWT           *ConsulACLAuthMethodJWTModel        `tfsdk:"jwt"`
+	OIDC          *ConsulACLAuthMethodOIDCModel       `tfsdk:"oidc"`
+	Kubernetes    *ConsulACLAuthMethodKubernetesModel `tfsdk:"kubernetes"`
+	Id            types.String                        `tfsdk:"id"`
+}
+
+// ConsulACLAuthMethodJWTModel describes the configuration of a jwt auth method.
+type ConsulACLAuthMethodJWTModel struct {
+	JWKSURL              types.String            `tfsdk:"jwks_url"`
+	JWKSCACert           types.String            `tfsdk:"jwks_ca_cert"`
+	JWTValidationPubKeys []types.String          `tfsdk:"jwt_validation_pub_keys"`
+	OIDCDiscoveryURL     types.String            `tfsdk:"oidc_discovery_url"`
+	OIDCDiscoveryCACert  types.String            `tfsdk:"oidc_discovery_ca_cert"`
+	BoundIssuer          types.String            `tfsdk:"bound_issuer"`
+	BoundAudiences       []types.String          `tfsdk:"bound_audiences"`
+	ClaimMappings        map[string]types.String `tfsdk:"claim_mappings"`
+	ListClaimMappings    map[string]types.String `tfsdk:"list_claim_mappings"`
+	JWTSupportedAlgs     []types.String          `tfsdk:"jwt_supported_algs"`
+	ExpirationLeeway     types.String            `tfsdk:"expiration_leeway"`
+	NotBeforeLeeway      types.String            `tfsdk:"not_before_leeway"`
+	ClockSkewLeeway      types.String            `tfsdk:"clock_skew_leeway"`
+}
+
+// ConsulACLAuthMethodOIDCModel describes the configuration of an oidc auth method.
+type ConsulACLAuthMethodOIDCModel struct {
+	OIDCDiscoveryURL    types.String            `tfsdk:"oidc_discovery_url"`
+	OIDCDiscoveryCACert types.String            `tfsdk:"oidc_discovery_ca_cert"`
+	OIDCClientID        types.String            `tfsdk:"oidc_client_id"`
+	OIDCClientSecret    types.String            `tfsdk:"oidc_client_secret"`
+	OIDCScopes          []types.String          `tfsdk:"oidc_scopes"`
+	OIDCACRValues       []types.String          `tfsdk:"oidc_acr_values"`
+	AllowedRedirectURIs []types.String          `tfsdk:"allowed_redirect_uris"`
+	BoundAudiences      []types.String          `tfsdk:"bound_audiences"`
+	ClaimMappings       map[string]types.String `tfsdk:"claim_mappings"`
+	ListClaimMappings   map[string]types.String `tfsdk:"list_claim_mappings"`
+	JWTSupportedAlgs    []types.String          `tfsdk:"jwt_supported_algs"`
+	VerboseOIDCLogging  types.Bool              `tfsdk:"verbose_oidc_logging"`
+}
+
+// ConsulACLAuthMethodKubernetesModel describes the configuration of a kubernetes auth method.
+type ConsulACLAuthMethodKubernetesModel struct {
+	Host              types.String `tfsdk:"host"`
+	CACert            types.String `tfsdk:"ca_cert"`
+	ServiceAccountJWT types.String `tfsdk:"service_account_jwt"`
+}
+
+// authMethodConfig is the configuration of an auth method as stored by
+// consul. The leeways are kept raw since they can be either Go duration
+// strings or numbers of nanoseconds, depending on how they were written.
+type authMethodConfig struct {
+	api.OIDCAuthMethodConfig
+	api.KubernetesAuthMethodConfig
+
+	ExpirationLeeway any
+	NotBeforeLeeway  any
+	ClockSkewLeeway  any
+}
+
+func (r *ConsulACLAuthMethodResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_acl_auth_method"
+}
+
+// tokenClaimAttributes returns the attributes shared by the jwt and the oidc
+// auth methods, which map the claims of the tokens to the binding rules.
+func tokenClaimAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"oidc_discovery_url": schema.StringAttribute{
+			MarkdownDescription: "The OIDC discovery URL of the issuer, without the `/.well-known/openid-configuration` suffix",
+			Optional:            true,
+		},
+		"oidc_discovery_ca_cert": schema.StringAttribute{
+			MarkdownDescription: "The PEM encoded CA certificate used to verify the OIDC discovery URL",
+			Optional:            true,
+		},
+		"bound_audiences": schema.ListAttribute{
+			MarkdownDescription: "The values of the `aud` claim, one of which is required in the tokens",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"claim_mappings": schema.MapAttribute{
+			MarkdownDescription: "The claims of the tokens made available to the selectors of the binding rules, keyed by claim name",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"list_claim_mappings": schema.MapAttribute{
+			MarkdownDescription: "The list claims of the tokens made available to the selectors of the binding rules, keyed by claim name",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"jwt_supported_algs": schema.ListAttribute{
+			MarkdownDescription: "The signing algorithms accepted for the tokens. Defaults to `RS256`.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+	}
+}
+
+func (r *ConsulACLAuthMethodResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	jwtAttributes := tokenClaimAttributes()
+	jwtAttributes["jwks_url"] = schema.StringAttribute{
+		MarkdownDescription: "The URL of the JSON Web Key Set used to verify the signatures of the tokens",
+		Optional:            true,
+	}
+	jwtAttributes["jwks_ca_cert"] = schema.StringAttribute{
+		MarkdownDescription: "The PEM encoded CA certificate used to verify the JWKS URL",
+		Optional:            true,
+	}
+	jwtAttributes["jwt_validation_pub_keys"] = schema.ListAttribute{
+		MarkdownDescription: "The PEM encoded public keys used to verify the signatures of the tokens",
+		ElementType:         types.StringType,
+		Optional:            true,
+	}
+	jwtAttributes["bound_issuer"] = schema.StringAttribute{
+		MarkdownDescription: "The value of the `iss` claim required in the tokens",
+		Optional:            true,
+	}
+	jwtAttributes["expiration_leeway"] = schema.StringAttribute{
+		MarkdownDescription: "The leeway applied to the `exp` claim, as a Go duration string",
+		Optional:            true,
+	}
+	jwtAttributes["not_before_leeway"] = schema.StringAttribute{
+		MarkdownDescription: "The leeway applied to the `nbf` claim, as a Go duration string",
+		Optional:            true,
+	}
+	jwtAttributes["clock_skew_leeway"] = schema.StringAttribute{
+		MarkdownDescription: "The leeway applied to all the time based claims, as a Go duration string",
+		Optional:            true,
+	}
+
+	oidcAttributes := tokenClaimAttributes()
+	oidcAttributes["oidc_discovery_url"] = schema.StringAttribute{
+		MarkdownDescription: "The OIDC discovery URL of the provider, without the `/.well-known/openid-configuration` suffix",
+		Required:            true,
+	}
+	oidcAttributes["oidc_client_id"] = schema.StringAttribute{
+		MarkdownDescription: "The OAuth client ID registered at the provider",
+		Required:            true,
+	}
+	oidcAttributes["oidc_client_secret"] = schema.StringAttribute{
+		MarkdownDescription: "The OAuth client secret registered at the provider",
+		Required:            true,
+		Sensitive:           true,
+	}
+	oidcAttributes["oidc_scopes"] = schema.ListAttribute{
+		MarkdownDescription: "The scopes requested in addition to `openid`",
+		ElementType:         types.StringType,
+		Optional:            true,
+	}
+	oidcAttributes["oidc_acr_values"] = schema.ListAttribute{
+		MarkdownDescription: "The Authentication Context Class Reference values requested",
+		ElementType:         types.StringType,
+		Optional:            true,
+	}
+	oidcAttributes["allowed_redirect_uris"] = schema.ListAttribute{
+		MarkdownDescription: "The redirect URIs allowed at the end of the login flow",
+		ElementType:         types.StringType,
+		Required:            true,
+	}
+	oidcAttributes["verbose_oidc_logging"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether the claims of the tokens are logged by consul, to debug the claim mappings",
+		Optional:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages an ACL auth method of type `jwt`, `oidc` or `kubernetes`, such as the one the `acl_auth_method` attribute of the provider logs in to.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the auth method",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the auth method, either `jwt`, `oidc` or `kubernetes`. The block of the same name configures it.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the auth method displayed in the UI",
+				Optional:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the auth method",
+				Optional:            true,
+			},
+			"max_token_ttl": schema.StringAttribute{
+				MarkdownDescription: "The maximum lifetime of the tokens created by the auth method, as a Go duration string",
+				Optional:            true,
+			},
+			"token_locality": schema.StringAttribute{
+				MarkdownDescription: "The kind of tokens created by the auth method, either `local` or `global`. Consul creates local tokens when unset.",
+				Optional:            true,
+			},
+			"jwt": schema.SingleNestedAttribute{
+				MarkdownDescription: "The configuration of a `jwt` auth method. Exactly one of `jwks_url`, `jwt_validation_pub_keys` and `oidc_discovery_url` is required.",
+				Optional:            true,
+				Attributes:          jwtAttributes,
+			},
+			"oidc": schema.SingleNestedAttribute{
+				MarkdownDescription: "The configuration of an `oidc` auth method",
+				Optional:            true,
+				Attributes:          oidcAttributes,
+			},
+			"kubernetes": schema.SingleNestedAttribute{
+				MarkdownDescription: "The configuration of a `kubernetes` auth method",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						MarkdownDescription: "The URL of the Kubernetes API server",
+						Required:            true,
+					},
+					"ca_cert": schema.StringAttribute{
+						MarkdownDescription: "The PEM encoded CA certificate of the Kubernetes API server",
+						Required:            true,
+					},
+					"service_account_jwt": schema.StringAttribute{
+						MarkdownDescription: "The JWT of the service account used to review the tokens of the login requests",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ACL auth method identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulACLAuthMethodResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ConsulACLAuthMethodResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var methodType, tokenLocality types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &methodType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("token_locality"), &tokenLocality)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !tokenLocality.IsNull() && !tokenLocality.IsUnknown() && tokenLocality.ValueString() != "local" && tokenLocality.ValueString() != "global" {
+		resp.Diagnostics.AddAttributeError(path.Root("token_locality"), "Invalid Token Locality", fmt.Sprintf("The token locality must be either local or global, got %q.", tokenLocality.ValueString()))
+	}
+
+	if methodType.IsUnknown() {
+		return
+	}
+
+	// Only the block matching the type of the auth method can be set
+	for _, blockType := range []string{jwtAuthMethodType, oidcAuthMethodType, kubernetesAuthMethodType} {
+		var block types.Object
+
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(blockType), &block)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if blockType == methodType.ValueString() && block.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(blockType), "Missing Auth Method Configuration", fmt.Sprintf("The %s block is required for an auth method of type %s.", blockType, blockType))
+		}
+
+		if blockType != methodType.ValueString() && !block.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(blockType), "Invalid Auth Method Configuration", fmt.Sprintf("The %s block cannot be set for an auth method of type %q.", blockType, methodType.ValueString()))
+		}
+
+		if blockType == jwtAuthMethodType && !block.IsNull() && !block.IsUnknown() {
+			validateJWTKeySources(block.Attributes(), resp)
+		}
+	}
+
+	switch methodType.ValueString() {
+	case jwtAuthMethodType, oidcAuthMethodType, kubernetesAuthMethodType:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("type"), "Invalid Auth Method Type", fmt.Sprintf("The type of the auth method must be either jwt, oidc or kubernetes, got %q.", methodType.ValueString()))
+	}
+}
+
+// validateJWTKeySources checks that the signatures of the tokens of a jwt auth
+// method are verified in exactly one way.
+func validateJWTKeySources(attributes map[string]attr.Value, resp *resource.ValidateConfigResponse) {
+	sources := 0
+
+	for _, attribute := range []string{"jwks_url", "jwt_validation_pub_keys", "oidc_discovery_url"} {
+		if attributes[attribute].IsUnknown() {
+			return
+		}
+
+		if !attributes[attribute].IsNull() {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root(jwtAuthMethodType), "Invalid JWT Key Source", fmt.Sprintf("Exactly one of jwks_url, jwt_validation_pub_keys and oidc_discovery_url is required, got %d.", sources))
+	}
+}
+
+// stringList returns the values of a list attribute.
+func stringList(values []types.String) []string {
+	var result []string
+
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+
+	return result
+}
+
+// stringMap returns the values of a map attribute.
+func stringMap(values map[string]types.String) map[string]string {
+	var result map[string]string
+
+	for key, value := range values {
+		if result == nil {
+			result = make(map[string]string, len(values))
+		}
+
+		result[key] = value.ValueString()
+	}
+
+	return result
+}
+
+// optionalStringValues returns the list attribute of values, keeping the prior
+// null or empty list when there are no values.
+func optionalStringValues(prior []types.String, values []string) []types.String {
+	if len(values) == 0 && len(prior) == 0 {
+		return prior
+	}
+
+	return stringValues(values)
+}
+
+// optionalStringMapValues returns the map attribute of values, keeping the
+// prior null or empty map when there are no values.
+func optionalStringMapValues(prior map[string]types.String, values map[string]string) map[string]types.String {
+	if len(values) == 0 && len(prior) == 0 {
+		return prior
+	}
+
+	result := make(map[string]types.String, len(values))
+
+	for key, value := range values {
+		result[key] = types.StringValue(value)
+	}
+
+	return result
+}
+
+// optionalSecret returns the secret read from consul, keeping the prior
+// secret when consul redacts it.
+func optionalSecret(prior types.String, value string) types.String {
+	if value == "" {
+		return prior
+	}
+
+	return types.StringValue(value)
+}
+
+// setConfigValue sets the value in the config of an auth method unless it is
+// the zero value, so that the config only holds the attributes which are set.
+func setConfigValue(config map[string]any, key string, value any) {
+	switch value := value.(type) {
+	case string:
+		if value == "" {
+			return
+		}
+	case []string:
+		if len(value) == 0 {
+			return
+		}
+	case map[string]string:
+		if len(value) == 0 {
+			return
+		}
+	case bool:
+		if !value {
+			return
+		}
+	}
+
+	config[key] = value
+}
+
+// configDuration returns the duration of a raw leeway of the config of an auth method.
+func configDuration(value any) (time.Duration, error) {
+	switch value := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return time.Duration(value), nil
+	case string:
+		return time.ParseDuration(value)
+	}
+
+	return 0, fmt.Errorf("unexpected duration %v", value)
+}
+
+// authMethod returns the auth method described by data.
+func (data ConsulACLAuthMethodResourceModel) authMethod() (*api.ACLAuthMethod, error) {
+	maxTokenTTL, err := parseOptionalDuration("max_token_ttl", data.MaxTokenTTL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]any{}
+
+	switch {
+	case data.JWT != nil:
+		setConfigValue(config, "JWKSURL", data.JWT.JWKSURL.ValueString())
+		setConfigValue(config, "JWKSCACert", data.JWT.JWKSCACert.ValueString())
+		setConfigValue(config, "JWTValidationPubKeys", stringList(data.JWT.JWTValidationPubKeys))
+		setConfigValue(config, "OIDCDiscoveryURL", data.JWT.OIDCDiscoveryURL.ValueString())
+		setConfigValue(config, "OIDCDiscoveryCACert", data.JWT.OIDCDiscoveryCACert.ValueString())
+		setConfigValue(config, "BoundIssuer", data.JWT.BoundIssuer.ValueString())
+		setConfigValue(config, "BoundAudiences", stringList(data.JWT.BoundAudiences))
+		setConfigValue(config, "ClaimMappings", stringMap(data.JWT.ClaimMappings))
+		setConfigValue(config, "ListClaimMappings", stringMap(data.JWT.ListClaimMappings))
+		setConfigValue(config, "JWTSupportedAlgs", stringList(data.JWT.JWTSupportedAlgs))
+
+		leeways := []struct {
+			attribute string
+			key       string
+			value     types.String
+		}{
+			{"expiration_leeway", "ExpirationLeeway", data.JWT.ExpirationLeeway},
+			{"not_before_leeway", "NotBeforeLeeway", data.JWT.NotBeforeLeeway},
+			{"clock_skew_leeway", "ClockSkewLeeway", data.JWT.ClockSkewLeeway},
+		}
+
+		for _, leeway := range leeways {
+			duration, err := parseOptionalDuration(leeway.attribute, leeway.value)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if duration != 0 {
+				config[leeway.key] = duration.String()
+			}
+		}
+	case data.OIDC != nil:
+		setConfigValue(config, "OIDCDiscoveryURL", data.OIDC.OIDCDiscoveryURL.ValueString())
+		setConfigValue(config, "OIDCDiscoveryCACert", data.OIDC.OIDCDiscoveryCACert.ValueString())
+		setConfigValue(config, "OIDCClientID", data.OIDC.OIDCClientID.ValueString())
+		setConfigValue(config, "OIDCClientSecret", data.OIDC.OIDCClientSecret.ValueString())
+		setConfigValue(config, "OIDCScopes", stringList(data.OIDC.OIDCScopes))
+		setConfigValue(config, "OIDCACRValues", stringList(data.OIDC.OIDCACRValues))
+		setConfigValue(config, "AllowedRedirectURIs", stringList(data.OIDC.AllowedRedirectURIs))
+		setConfigValue(config, "BoundAudiences", stringList(data.OIDC.BoundAudiences))
+		setConfigValue(config, "ClaimMappings", stringMap(data.OIDC.ClaimMappings))
+		setConfigValue(config, "ListClaimMappings", stringMap(data.OIDC.ListClaimMappings))
+		setConfigValue(config, "JWTSupportedAlgs", stringList(data.OIDC.JWTSupportedAlgs))
+		setConfigValue(config, "VerboseOIDCLogging", data.OIDC.VerboseOIDCLogging.ValueBool())
+	case data.Kubernetes != nil:
+		setConfigValue(config, "Host", data.Kubernetes.Host.ValueString())
+		setConfigValue(config, "CACert", data.Kubernetes.CACert.ValueString())
+		setConfigValue(config, "ServiceAccountJWT", data.Kubernetes.ServiceAccountJWT.ValueString())
+	}
+
+	return &api.ACLAuthMethod{
+		Name:          data.Name.ValueString(),
+		Type:          data.Type.ValueString(),
+		DisplayName:   data.DisplayName.ValueString(),
+		Description:   data.Description.ValueString(),
+		MaxTokenTTL:   maxTokenTTL,
+		TokenLocality: data.TokenLocality.ValueString(),
+		Config:        config,
+	}, nil
+}
+
+// readAuthMethod sets data from the auth method read from consul.
+func (data *ConsulACLAuthMethodResourceModel) readAuthMethod(method *api.ACLAuthMethod) error {
+	encoded, err := json.Marshal(method.Config)
+
+	if err != nil {
+		return err
+	}
+
+	var config authMethodConfig
+
+	if err := json.Unmarshal(encoded, &config); err != nil {
+		return fmt.Errorf("invalid config of auth method %q: %w", method.Name, err)
+	}
+
+	data.Type = types.StringValue(method.Type)
+	data.DisplayName = optionalString(method.DisplayName)
+	data.Description = optionalString(method.Description)
+	data.MaxTokenTTL = durationValue(data.MaxTokenTTL, method.MaxTokenTTL)
+	data.TokenLocality = optionalString(method.TokenLocality)
+
+	priorJWT, priorOIDC, priorKubernetes := data.JWT, data.OIDC, data.Kubernetes
+
+	data.JWT, data.OIDC, data.Kubernetes = nil, nil, nil
+
+	switch method.Type {
+	case jwtAuthMethodType:
+		if priorJWT == nil {
+			priorJWT = &ConsulACLAuthMethodJWTModel{}
+		}
+
+		data.JWT = &ConsulACLAuthMethodJWTModel{
+			JWKSURL:              optionalString(config.JWKSURL),
+			JWKSCACert:           optionalString(config.JWKSCACert),
+			JWTValidationPubKeys: optionalStringValues(priorJWT.JWTValidationPubKeys, config.JWTValidationPubKeys),
+			OIDCDiscoveryURL:     optionalString(config.OIDCDiscoveryURL),
+			OIDCDiscoveryCACert:  optionalString(config.OIDCDiscoveryCACert),
+			BoundIssuer:          optionalString(config.BoundIssuer),
+			BoundAudiences:       optionalStringValues(priorJWT.BoundAudiences, config.BoundAudiences),
+			ClaimMappings:        optionalStringMapValues(priorJWT.ClaimMappings, config.ClaimMappings),
+			ListClaimMappings:    optionalStringMapValues(priorJWT.ListClaimMappings, config.ListClaimMappings),
+			JWTSupportedAlgs:     optionalStringValues(priorJWT.JWTSupportedAlgs, config.JWTSupportedAlgs),
+		}
+
+		leeways := []struct {
+			target *types.String
+			prior  types.String
+			value  any
+		}{
+			{&data.JWT.ExpirationLeeway, priorJWT.ExpirationLeeway, config.ExpirationLeeway},
+			{&data.JWT.NotBeforeLeeway, priorJWT.NotBeforeLeeway, config.NotBeforeLeeway},
+			{&data.JWT.ClockSkewLeeway, priorJWT.ClockSkewLeeway, config.ClockSkewLeeway},
+		}
+
+		for _, leeway := range leeways {
+			duration, err := configDuration(leeway.value)
+
+			if err != nil {
+				return fmt.Errorf("invalid config of auth method %q: %w", method.Name, err)
+			}
+
+			*leeway.target = durationValue(leeway.prior, duration)
+		}
+	case oidcAuthMethodType:
+		if priorOIDC == nil {
+			priorOIDC = &ConsulACLAuthMethodOIDCModel{}
+		}
+
+		data.OIDC = &ConsulACLAuthMethodOIDCModel{
+			OIDCDiscoveryURL:    optionalString(config.OIDCDiscoveryURL),
+			OIDCDiscoveryCACert: optionalString(config.OIDCDiscoveryCACert),
+			OIDCClientID:        optionalString(config.OIDCClientID),
+			OIDCClientSecret:    optionalSecret(priorOIDC.OIDCClientSecret, config.OIDCClientSecret),
+			OIDCScopes:          optionalStringValues(priorOIDC.OIDCScopes, config.OIDCScopes),
+			OIDCACRValues:       optionalStringValues(priorOIDC.OIDCACRValues, config.OIDCACRValues),
+			AllowedRedirectURIs: optionalStringValues(priorOIDC.AllowedRedirectURIs, config.AllowedRedirectURIs),
+			BoundAudiences:      optionalStringValues(priorOIDC.BoundAudiences, config.BoundAudiences),
+			ClaimMappings:       optionalStringMapValues(priorOIDC.ClaimMappings, config.ClaimMappings),
+			ListClaimMappings:   optionalStringMapValues(priorOIDC.ListClaimMappings, config.ListClaimMappings),
+			JWTSupportedAlgs:    optionalStringValues(priorOIDC.JWTSupportedAlgs, config.JWTSupportedAlgs),
+			VerboseOIDCLogging:  types.BoolNull(),
+		}
+
+		if config.VerboseOIDCLogging || !priorOIDC.VerboseOIDCLogging.IsNull() {
+			data.OIDC.VerboseOIDCLogging = types.BoolValue(config.VerboseOIDCLogging)
+		}
+	case kubernetesAuthMethodType:
+		if priorKubernetes == nil {
+			priorKubernetes = &ConsulACLAuthMethodKubernetesModel{}
+		}
+
+		data.Kubernetes = &ConsulACLAuthMethodKubernetesModel{
+			Host:              types.StringValue(config.Host),
+			CACert:            types.StringValue(config.CACert),
+			ServiceAccountJWT: optionalSecret(priorKubernetes.ServiceAccountJWT, config.ServiceAccountJWT),
+		}
+	default:
+		return fmt.Errorf("the type %q of auth method %q is not supported", method.Type, method.Name)
+	}
+
+	return nil
+}
+
+func (r *ConsulACLAuthMethodResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulACLAuthMethodResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	method, err := data.authMethod()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Auth Method", err.Error())
+		return
+	}
+
+	_, _, err = client.ACL().AuthMethodCreate(method, nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL auth method, got error: %s", err))
+		return
+	}
+
+	data.Id = data.Name
+
+	tflog.Debug(ctx, "acl auth method")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLAuthMethodResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulACLAuthMethodResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	method, _, err := client.ACL().AuthMethodRead(data.Name.ValueString(), nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL auth method, got error: %s", err))
+		return
+	}
+
+	if method == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := data.readAuthMethod(method); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL auth method, got error: %s", err))
+		return
+	}
+
+	data.Id = data.Name
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLAuthMethodResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulACLAuthMethodResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	method, err := data.authMethod()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Auth Method", err.Error())
+		return
+	}
+
+	_, _, err = client.ACL().AuthMethodUpdate(method, nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL auth method, got error: %s", err))
+		return
+	}
+
+	data.Id = data.Name
+
+	tflog.Debug(ctx, "acl auth method")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLAuthMethodResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulACLAuthMethodResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	_, err = client.ACL().AuthMethodDelete(data.Name.ValueString(), nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ACL auth method, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulACLAuthMethodResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The ID of an auth method is its name, which may contain underscores
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
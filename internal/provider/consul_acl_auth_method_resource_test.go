@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulACLAuthMethodResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckACL(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_acl_auth_method" "test" {
+	name = "utils-test"
+	type = "jwt"
+
+	jwt = {
+		jwks_url       = "https://issuer.example.com/.well-known/jwks.json"
+		bound_issuer   = "https://issuer.example.com"
+		claim_mappings = {
+			sub = "subject"
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_auth_method.test", "jwt.claim_mappings.sub", "subject"),
+					resource.TestCheckResourceAttr("utils_consul_acl_auth_method.test", "id", "utils-test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_acl_auth_method.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_acl_auth_method" "test" {
+	name          = "utils-test"
+	type          = "jwt"
+	max_token_ttl = "1h"
+
+	jwt = {
+		jwks_url          = "https://issuer.example.com/.well-known/jwks.json"
+		bound_issuer      = "https://issuer.example.com"
+		clock_skew_leeway = "30s"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_auth_method.test", "max_token_ttl", "1h"),
+					resource.TestCheckResourceAttr("utils_consul_acl_auth_method.test", "jwt.clock_skew_leeway", "30s"),
+					resource.TestCheckNoResourceAttr("utils_consul_acl_auth_method.test", "jwt.claim_mappings"),
+				),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestACLAuthMethodConfig(t *testing.T) {
+	data := ConsulACLAuthMethodResourceModel{
+		Name:        types.StringValue("platform"),
+		Type:        types.StringValue(jwtAuthMethodType),
+		MaxTokenTTL: types.StringValue("60m"),
+		JWT: &ConsulACLAuthMethodJWTModel{
+			JWKSURL:          types.StringValue("https://issuer.example.com/jwks"),
+			BoundAudiences:   []types.String{},
+			ClaimMappings:    map[string]types.String{"sub": types.StringValue("subject")},
+			ClockSkewLeeway:  types.StringValue("30s"),
+			NotBeforeLeeway:  types.StringNull(),
+			ExpirationLeeway: types.StringNull(),
+		},
+	}
+
+	method, err := data.authMethod()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"JWKSURL":         "https://issuer.example.com/jwks",
+		"ClaimMappings":   map[string]string{"sub": "subject"},
+		"ClockSkewLeeway": "30s",
+	}
+
+	if !reflect.DeepEqual(method.Config, expected) || method.MaxTokenTTL != time.Hour {
+		t.Fatalf("expected only the set attributes in the config, got %+v", method)
+	}
+
+	// Consul may return the leeways as numbers of nanoseconds
+	method.Config = map[string]any{
+		"JWKSURL":          "https://issuer.example.com/jwks",
+		"ClaimMappings":    map[string]any{"sub": "subject"},
+		"ClockSkewLeeway":  float64(30 * time.Second),
+		"ExpirationLeeway": "5s",
+	}
+
+	read := data
+
+	if err := read.readAuthMethod(method); err != nil {
+		t.Fatal(err)
+	}
+
+	if read.MaxTokenTTL.ValueString() != "60m" || read.JWT.ClockSkewLeeway.ValueString() != "30s" || read.JWT.ExpirationLeeway.ValueString() != "5s" {
+		t.Errorf("expected the durations to be read, got %+v", read.JWT)
+	}
+
+	if read.JWT.BoundAudiences == nil || len(read.JWT.BoundAudiences) != 0 || read.JWT.ClaimMappings["sub"].ValueString() != "subject" {
+		t.Errorf("expected the lists and the maps to be read as configured, got %+v", read.JWT)
+	}
+
+	// The secrets redacted by consul are kept as configured
+	data = ConsulACLAuthMethodResourceModel{
+		Name: types.StringValue("kubernetes"),
+		Type: types.StringValue(kubernetesAuthMethodType),
+		Kubernetes: &ConsulACLAuthMethodKubernetesModel{
+			Host:              types.StringValue("https://kubernetes.default.svc"),
+			CACert:            types.StringValue("-----BEGIN CERTIFICATE-----"),
+			ServiceAccountJWT: types.StringValue("secret"),
+		},
+	}
+
+	err = data.readAuthMethod(&api.ACLAuthMethod{
+		Name:   "kubernetes",
+		Type:   kubernetesAuthMethodType,
+		Config: map[string]any{"Host": "https://kubernetes.default.svc", "CACert": "-----BEGIN CERTIFICATE-----"},
+	})
+
+	if err != nil || data.Kubernetes.ServiceAccountJWT.ValueString() != "secret" {
+		t.Errorf("expected the service account JWT to be kept, got %+v, %v", data.Kubernetes, err)
+	}
+
+	if err := data.readAuthMethod(&api.ACLAuthMethod{Name: "aws", Type: "aws-iam"}); err == nil {
+		t.Error("expected an error for an unsupported auth method type")
+	}
+}
+
+func TestConsulACLAuthMethodResourceImportState(t *testing.T) {
+	testImportState(t, &ConsulACLAuthMethodResource{}, "utils_test", map[string]attr.Value{
+		"name": types.StringValue("utils_test"),
+		"type": types.StringNull(),
+		"id":   types.StringValue("utils_test"),
+	})
+}
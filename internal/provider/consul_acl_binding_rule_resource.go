@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulACLBindingRuleResource{}
+var _ resource.ResourceWithImportState = &ConsulACLBindingRuleResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulACLBindingRuleResource{}
+
+func NewConsulACLBindingRuleResource() resource.Resource {
+	return &ConsulACLBindingRuleResource{}
+}
+
+// ConsulACLBindingRuleResource defines the resource implementation.
+type ConsulACLBindingRuleResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulACLBindingRuleResourceModel describes the resource data model.
+type ConsulACLBindingRuleResourceModel struct {
+	Cluster     types.String `tfsdk:"cluster"`
+	AuthMethod  types.String `tfsdk:"auth_method"`
+	Description types.String `tfsdk:"description"`
+	Selector    types.String `tfsdk:"selector"`
+	BindType    types.String `tfsdk:"bind_type"`
+	BindName    types.String `tfsdk:"bind_name"`
+	BindVarName types.String `tfsdk:"bind_var_name"`
+	Id          types.String `tfsdk:"id"`
+}
+
+func (r *ConsulACLBindingRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_acl_binding_rule"
+}
+
+func (r *ConsulACLBindingRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages an ACL binding rule, granting the tokens created by an auth method a service identity, a node identity, a role, a policy or a templated policy.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auth_method": schema.StringAttribute{
+				MarkdownDescription: "The name of the auth method the binding rule applies to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the binding rule",
+				Optional:            true,
+			},
+			"selector": schema.StringAttribute{
+				MarkdownDescription: "The expression matching the claims mapped by the auth method, such as `value.team == \"platform\"`. The binding rule applies to every login when unset.",
+				Optional:            true,
+			},
+			"bind_type": schema.StringAttribute{
+				MarkdownDescription: "What the tokens are granted, either `service`, `node`, `role`, `policy` or `templated-policy`",
+				Required:            true,
+			},
+			"bind_name": schema.StringAttribute{
+				MarkdownDescription: "The name of what the tokens are granted, which can interpolate the mapped claims, such as `${value.service}`",
+				Required:            true,
+			},
+			"bind_var_name": schema.StringAttribute{
+				MarkdownDescription: "The `name` variable of the templated policy, which can interpolate the mapped claims. Only valid with the `templated-policy` bind type.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ACL binding rule identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulACLBindingRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ConsulACLBindingRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConsulACLBindingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.BindType.IsUnknown() {
+		return
+	}
+
+	switch api.BindingRuleBindType(data.BindType.ValueString()) {
+	case api.BindingRuleBindTypeService, api.BindingRuleBindTypeNode, api.BindingRuleBindTypeRole, api.BindingRuleBindTypePolicy:
+		if !data.BindVarName.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("bind_var_name"), "Invalid Bind Variable", "The bind_var_name attribute is only valid with the templated-policy bind type.")
+		}
+	case api.BindingRuleBindTypeTemplatedPolicy:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("bind_type"), "Invalid Bind Type", fmt.Sprintf("The bind type must be either service, node, role, policy or templated-policy, got %q.", data.BindType.ValueString()))
+	}
+}
+
+// bindingRule returns the binding rule described by data.
+func (data ConsulACLBindingRuleResourceModel) bindingRule() *api.ACLBindingRule {
+	rule := &api.ACLBindingRule{
+		ID:          data.Id.ValueString(),
+		Description: data.Description.ValueString(),
+		AuthMethod:  data.AuthMethod.ValueString(),
+		Selector:    data.Selector.ValueString(),
+		BindType:    api.BindingRuleBindType(data.BindType.ValueString()),
+		BindName:    data.BindName.ValueString(),
+	}
+
+	if !data.BindVarName.IsNull() {
+		rule.BindVars = &api.ACLTemplatedPolicyVariables{Name: data.BindVarName.ValueString()}
+	}
+
+	return rule
+}
+
+// readBindingRule sets data from the binding rule read from consul.
+func (data *ConsulACLBindingRuleResourceModel) readBindingRule(rule *api.ACLBindingRule) {
+	data.Id = types.StringValue(rule.ID)
+	data.AuthMethod = types.StringValue(rule.AuthMethod)
+	data.Description = optionalString(rule.Description)
+	data.Selector = optionalString(rule.Selector)
+	data.BindType = types.StringValue(string(rule.BindType))
+	data.BindName = types.StringValue(rule.BindName)
+	data.BindVarName = types.StringNull()
+
+	if rule.BindVars != nil {
+		data.BindVarName = optionalString(rule.BindVars.Name)
+	}
+}
+
+func (r *ConsulACLBindingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulACLBindingRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	// The ID of the binding rule is generated by consul
+	data.Id = types.StringNull()
+
+	rule, _, err := client.ACL().BindingRuleCreate(data.bindingRule(), nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL binding rule, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(rule.ID)
+
+	tflog.Debug(ctx, "acl binding rule")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLBindingRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulACLBindingRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	rule, _, err := client.ACL().BindingRuleRead(data.Id.ValueString(), nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL binding rule, got error: %s", err))
+		return
+	}
+
+	// Consul deletes the binding rules of a deleted auth method
+	if rule == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.readBindingRule(rule)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLBindingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulACLBindingRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	_, _, err = client.ACL().BindingRuleUpdate(data.bindingRule(), nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL binding rule, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "acl binding rule")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLBindingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulACLBindingRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	_, err = client.ACL().BindingRuleDelete(data.Id.ValueString(), nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ACL binding rule, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulACLBindingRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Binding rules have no name, they are imported from the ID consul generated
+	if !IsValidUUID(req.ID) {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("invalid import ID %q, expected the UUID of the binding rule", req.ID))
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
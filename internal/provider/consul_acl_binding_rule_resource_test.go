@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulACLBindingRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckACL(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_acl_auth_method" "test" {
+	name = "utils-test-binding"
+	type = "jwt"
+
+	jwt = {
+		jwks_url       = "https://issuer.example.com/.well-known/jwks.json"
+		claim_mappings = {
+			service = "service"
+		}
+	}
+}
+
+resource "utils_consul_acl_binding_rule" "test" {
+	auth_method = utils_consul_acl_auth_method.test.name
+	bind_type   = "service"
+	bind_name   = "$${value.service}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_binding_rule.test", "bind_name", "${value.service}"),
+					resource.TestCheckResourceAttrSet("utils_consul_acl_binding_rule.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_acl_binding_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_acl_auth_method" "test" {
+	name = "utils-test-binding"
+	type = "jwt"
+
+	jwt = {
+		jwks_url       = "https://issuer.example.com/.well-known/jwks.json"
+		claim_mappings = {
+			service = "service"
+		}
+	}
+}
+
+resource "utils_consul_acl_binding_rule" "test" {
+	auth_method = utils_consul_acl_auth_method.test.name
+	selector    = "value.service != \"\""
+	bind_type   = "service"
+	bind_name   = "$${value.service}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_binding_rule.test", "selector", "value.service != \"\""),
+				),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestConsulACLBindingRuleResourceImportState(t *testing.T) {
+	testImportState(t, &ConsulACLBindingRuleResource{}, "8f246b77-f3e1-ff88-5b48-8ec93abf3e05", map[string]attr.Value{
+		"auth_method": types.StringNull(),
+		"id":          types.StringValue("8f246b77-f3e1-ff88-5b48-8ec93abf3e05"),
+	})
+
+	testImportState(t, &ConsulACLBindingRuleResource{}, "utils-test", nil)
+}
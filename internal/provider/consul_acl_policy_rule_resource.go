@@ -0,0 +1,409 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulACLPolicyRuleResource{}
+var _ resource.ResourceWithImportState = &ConsulACLPolicyRuleResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulACLPolicyRuleResource{}
+
+func NewConsulACLPolicyRuleResource() resource.Resource {
+	return &ConsulACLPolicyRuleResource{}
+}
+
+// ConsulACLPolicyRuleResource defines the resource implementation.
+type ConsulACLPolicyRuleResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulACLPolicyRuleResourceModel describes the resource data model.
+type ConsulACLPolicyRuleResourceModel struct {
+	Cluster    types.String `tfsdk:"cluster"`
+	Policy     types.String `tfsdk:"policy"`
+	Resource   types.String `tfsdk:"resource"`
+	Segment    types.String `tfsdk:"segment"`
+	Access     types.String `tfsdk:"access"`
+	Intentions types.String `tfsdk:"intentions"`
+	Id         types.String `tfsdk:"id"`
+}
+
+func (r *ConsulACLPolicyRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_acl_policy_rule"
+}
+
+func (r *ConsulACLPolicyRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single rule of the HCL rules of an existing ACL policy, such as `service \"web\" { policy = \"write\" }`, so that several teams can each grant their own access through a shared policy. The other rules of the policy are kept as written, and creating the resource fails when the policy already has a rule for the same resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "The name or the ID of the ACL policy",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource": schema.StringAttribute{
+				MarkdownDescription: "The resource of the rule, such as `service`, `key_prefix` or `operator`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"segment": schema.StringAttribute{
+				MarkdownDescription: "The segment of the rule, such as the name of the service. Required for every resource but `acl`, `keyring`, `mesh`, `operator` and `peering`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access": schema.StringAttribute{
+				MarkdownDescription: "The access level granted by the rule, either `read`, `write`, `list` or `deny`",
+				Required:            true,
+			},
+			"intentions": schema.StringAttribute{
+				MarkdownDescription: "The access level granted to the intentions of the services by a `service` or `service_prefix` rule, either `read`, `write` or `deny`",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ACL policy rule identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulACLPolicyRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ConsulACLPolicyRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConsulACLPolicyRuleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Resource.IsUnknown() {
+		return
+	}
+
+	labeled, ok := aclRuleResources[data.Resource.ValueString()]
+
+	if !ok {
+		resources := make([]string, 0, len(aclRuleResources))
+
+		for resource := range aclRuleResources {
+			resources = append(resources, resource)
+		}
+
+		sort.Strings(resources)
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource"),
+			"Invalid ACL Rule Resource",
+			fmt.Sprintf("The resource must be one of %s, got %q.", strings.Join(resources, ", "), data.Resource.ValueString()),
+		)
+
+		return
+	}
+
+	if labeled && data.Segment.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("segment"), "Missing ACL Rule Segment", fmt.Sprintf("The rules of the %s resource require a segment.", data.Resource.ValueString()))
+	}
+
+	if !labeled && !data.Segment.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("segment"), "Unexpected ACL Rule Segment", fmt.Sprintf("The rules of the %s resource have no segment.", data.Resource.ValueString()))
+	}
+
+	if access := data.Access.ValueString(); !data.Access.IsUnknown() && access != "read" && access != "write" && access != "list" && access != "deny" {
+		resp.Diagnostics.AddAttributeError(path.Root("access"), "Invalid ACL Rule Access", fmt.Sprintf("The access must be either read, write, list or deny, got %q.", access))
+	}
+
+	if data.Intentions.IsNull() || data.Intentions.IsUnknown() {
+		return
+	}
+
+	if resource := data.Resource.ValueString(); resource != "service" && resource != "service_prefix" {
+		resp.Diagnostics.AddAttributeError(path.Root("intentions"), "Unexpected ACL Rule Intentions", "Only the rules of the service and service_prefix resources grant access to the intentions.")
+	}
+
+	if intentions := data.Intentions.ValueString(); intentions != "read" && intentions != "write" && intentions != "deny" {
+		resp.Diagnostics.AddAttributeError(path.Root("intentions"), "Invalid ACL Rule Intentions", fmt.Sprintf("The intentions access must be either read, write or deny, got %q.", intentions))
+	}
+}
+
+func (data ConsulACLPolicyRuleResourceModel) rule() aclPolicyRule {
+	return aclPolicyRule{
+		Resource:   data.Resource.ValueString(),
+		Segment:    data.Segment.ValueString(),
+		Access:     data.Access.ValueString(),
+		Intentions: data.Intentions.ValueString(),
+	}
+}
+
+func (data ConsulACLPolicyRuleResourceModel) setRule(rules string) (string, error) {
+	return setACLPolicyRule(rules, data.rule())
+}
+
+// addRule adds the rule of data to the rules, refusing the rule of the same
+// resource already there, as it belongs to another owner.
+func (data ConsulACLPolicyRuleResourceModel) addRule(rules string) (string, error) {
+	_, found, err := readACLPolicyRule(rules, data.Resource.ValueString(), data.Segment.ValueString())
+
+	if err != nil {
+		return "", err
+	}
+
+	if found {
+		return "", fmt.Errorf("a rule for the same resource already exists")
+	}
+
+	return data.setRule(rules)
+}
+
+func (data ConsulACLPolicyRuleResourceModel) removeRule(rules string) (string, error) {
+	return removeACLPolicyRule(rules, data.Resource.ValueString(), data.Segment.ValueString())
+}
+
+func (data ConsulACLPolicyRuleResourceModel) id() string {
+	if data.Segment.IsNull() {
+		return fmt.Sprintf("%s_%s", data.Policy.ValueString(), data.Resource.ValueString())
+	}
+
+	return fmt.Sprintf("%s_%s_%s", data.Policy.ValueString(), data.Resource.ValueString(), data.Segment.ValueString())
+}
+
+func (r *ConsulACLPolicyRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulACLPolicyRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateACLPolicyRules(client, data.Policy.ValueString(), data.addRule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL policy, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "acl policy rule")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLPolicyRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulACLPolicyRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	policy, err := readACLPolicy(client, data.Policy.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL policy, got error: %s", err))
+		return
+	}
+
+	if policy == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	rule, found, err := readACLPolicyRule(policy.Rules, data.Resource.ValueString(), data.Segment.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL policy, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Access = types.StringValue(rule.Access)
+	data.Intentions = optionalString(rule.Intentions)
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLPolicyRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulACLPolicyRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateACLPolicyRules(client, data.Policy.ValueString(), data.setRule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL policy, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "acl policy rule")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLPolicyRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulACLPolicyRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	policy, err := readACLPolicy(client, data.Policy.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL policy, got error: %s", err))
+		return
+	}
+
+	// Nothing to remove from a deleted policy
+	if policy != nil {
+		err = updateACLPolicyRules(client, policy.ID, data.removeRule)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL policy, got error: %s", err))
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// parseACLPolicyRuleID splits the ID of a rule into its policy, resource and
+// segment. As resources such as key_prefix contain underscores, the first
+// known resource followed by a segment when its rules are labeled is used,
+// the segment being the rest of the ID.
+func parseACLPolicyRuleID(id string) (policy, ruleResource string, segment *string, err error) {
+	parts := strings.Split(id, "_")
+
+	for i := 1; i < len(parts); i++ {
+		// The resources are made of at most two parts, the longest one being tried first
+		for j := min(i+2, len(parts)); j > i; j-- {
+			labeled, ok := aclRuleResources[strings.Join(parts[i:j], "_")]
+
+			policy = strings.Join(parts[:i], "_")
+
+			if !ok || labeled == (j == len(parts)) || strings.Trim(policy, "_") == "" {
+				continue
+			}
+
+			ruleResource = strings.Join(parts[i:j], "_")
+
+			if labeled {
+				rest := strings.Join(parts[j:], "_")
+				segment = &rest
+			}
+
+			return policy, ruleResource, segment, nil
+		}
+	}
+
+	return "", "", nil, invalidImportIDError(id, [][]string{{"policy", "resource"}, {"policy", "resource", "segment"}})
+}
+
+func (r *ConsulACLPolicyRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	policy, ruleResource, segment, err := parseACLPolicyRuleID(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy"), policy)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource"), ruleResource)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("segment"), segment)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccCreateACLPolicy creates the ACL policy the rules of the tests are added to.
+func testAccCreateACLPolicy(t *testing.T, name string) {
+	client, err := api.NewClient(api.DefaultConfig())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policy, err := readACLPolicy(client, name); err != nil || policy != nil {
+		return
+	}
+
+	if _, _, err := client.ACL().PolicyCreate(&api.ACLPolicy{Name: name, Rules: `operator = "read"`}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAccConsulACLPolicyRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckACL(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: func() { testAccCreateACLPolicy(t, "utils-test") },
+				Config: `
+resource "utils_consul_acl_policy_rule" "test" {
+	policy   = "utils-test"
+	resource = "service"
+	segment  = "web"
+	access   = "write"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_policy_rule.test", "access", "write"),
+					resource.TestCheckResourceAttr("utils_consul_acl_policy_rule.test", "id", "utils-test_service_web"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_acl_policy_rule" "test" {
+	policy     = "utils-test"
+	resource   = "service"
+	segment    = "web"
+	access     = "read"
+	intentions = "read"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_policy_rule.test", "access", "read"),
+					resource.TestCheckResourceAttr("utils_consul_acl_policy_rule.test", "intentions", "read"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_acl_policy_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestACLPolicyRules(t *testing.T) {
+	rules := `# Shared by the services of the platform
+service_prefix "" {
+  policy = "read"
+}
+
+operator = "read"
+`
+
+	steps := []struct {
+		update   func(rules string) (string, error)
+		expected string
+	}{
+		{
+			update: func(rules string) (string, error) {
+				return setACLPolicyRule(rules, aclPolicyRule{Resource: "service", Segment: "web", Access: "write", Intentions: "read"})
+			},
+			expected: `# Shared by the services of the platform
+service_prefix "" {
+  policy = "read"
+}
+
+operator = "read"
+
+service "web" {
+  policy     = "write"
+  intentions = "read"
+}
+`,
+		},
+		{
+			update: func(rules string) (string, error) {
+				return setACLPolicyRule(rules, aclPolicyRule{Resource: "operator", Access: "write"})
+			},
+			expected: `# Shared by the services of the platform
+service_prefix "" {
+  policy = "read"
+}
+
+operator = "write"
+
+service "web" {
+  policy     = "write"
+  intentions = "read"
+}
+`,
+		},
+		{
+			update: func(rules string) (string, error) {
+				return removeACLPolicyRule(rules, "service", "web")
+			},
+			expected: `# Shared by the services of the platform
+service_prefix "" {
+  policy = "read"
+}
+
+operator = "write"
+`,
+		},
+	}
+
+	for i, step := range steps {
+		var err error
+
+		if rules, err = step.update(rules); err != nil {
+			t.Fatal(err)
+		}
+
+		if rules != step.expected {
+			t.Fatalf("step %d: expected rules\n%s\ngot\n%s", i, step.expected, rules)
+		}
+	}
+
+	rule, found, err := readACLPolicyRule(rules, "service_prefix", "")
+
+	if err != nil || !found || rule.Access != "read" {
+		t.Errorf("expected the service_prefix rule to be read, got %+v, %v, %v", rule, found, err)
+	}
+
+	if _, found, _ := readACLPolicyRule(rules, "service", "web"); found {
+		t.Error("expected the removed rule not to be found")
+	}
+
+	if _, err := setACLPolicyRule(`{"operator": "read"}`, aclPolicyRule{Resource: "operator", Access: "write"}); err == nil {
+		t.Error("expected an error for rules in JSON syntax")
+	}
+
+	// The rule of another owner is only replaced on update
+	operator := ConsulACLPolicyRuleResourceModel{Resource: types.StringValue("operator"), Segment: types.StringNull(), Access: types.StringValue("read")}
+
+	if _, err := operator.addRule(rules); err == nil {
+		t.Error("expected an error when adding a rule for a resource which already has one")
+	}
+}
+
+func TestConsulACLPolicyRuleResourceImportState(t *testing.T) {
+	r := &ConsulACLPolicyRuleResource{}
+
+	testImportState(t, r, "utils-test_service_web", map[string]attr.Value{
+		"policy":   types.StringValue("utils-test"),
+		"resource": types.StringValue("service"),
+		"segment":  types.StringValue("web"),
+		"id":       types.StringValue("utils-test_service_web"),
+	})
+
+	testImportState(t, r, "utils_test_key_prefix_app_config", map[string]attr.Value{
+		"policy":   types.StringValue("utils_test"),
+		"resource": types.StringValue("key_prefix"),
+		"segment":  types.StringValue("app_config"),
+	})
+
+	testImportState(t, r, "utils-test_service_prefix_", map[string]attr.Value{
+		"resource": types.StringValue("service_prefix"),
+		"segment":  types.StringValue(""),
+	})
+
+	testImportState(t, r, "utils-test_operator", map[string]attr.Value{
+		"resource": types.StringValue("operator"),
+		"segment":  types.StringNull(),
+	})
+
+	for _, id := range []string{"utils-test", "utils-test_service", "utils-test_operator_read", "_service_web", "utils-test_unknown_web"} {
+		testImportState(t, r, id, nil)
+	}
+}
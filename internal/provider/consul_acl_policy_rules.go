@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The resources of the ACL rules, and whether their rules are blocks
+// labeled with a segment rather than attributes
+var aclRuleResources = map[string]bool{
+	"acl":             false,
+	"agent":           true,
+	"agent_prefix":    true,
+	"event":           true,
+	"event_prefix":    true,
+	"identity":        true,
+	"identity_prefix": true,
+	"key":             true,
+	"key_prefix":      true,
+	"keyring":         false,
+	"mesh":            false,
+	"node":            true,
+	"node_prefix":     true,
+	"operator":        false,
+	"peering":         false,
+	"query":           true,
+	"query_prefix":    true,
+	"service":         true,
+	"service_prefix":  true,
+	"session":         true,
+	"session_prefix":  true,
+}
+
+// aclPolicyRule is a single rule of the rules of an ACL policy.
+type aclPolicyRule struct {
+	Resource string
+	// Empty for the resources whose rules are attributes
+	Segment string
+	Access  string
+	// Empty when the rule does not grant access to the intentions
+	Intentions string
+}
+
+// parseACLRules parses the HCL rules of an ACL policy, keeping their formatting and comments.
+func parseACLRules(rules string) (*hclwrite.File, error) {
+	if strings.HasPrefix(strings.TrimSpace(rules), "{") {
+		return nil, fmt.Errorf("ACL rules in JSON syntax are not supported")
+	}
+
+	file, diags := hclwrite.ParseConfig([]byte(rules), "rules.hcl", hcl.InitialPos)
+
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse the ACL rules: %s", diags.Error())
+	}
+
+	return file, nil
+}
+
+// hclStringValue returns the value of an attribute holding a string literal.
+func hclStringValue(attribute *hclwrite.Attribute) (string, error) {
+	expression, diags := hclsyntax.ParseExpression(attribute.Expr().BuildTokens(nil).Bytes(), "rules.hcl", hcl.InitialPos)
+
+	if diags.HasErrors() {
+		return "", fmt.Errorf("unable to parse the ACL rules: %s", diags.Error())
+	}
+
+	value, diags := expression.Value(nil)
+
+	if diags.HasErrors() || value.IsNull() || !value.Type().Equals(cty.String) {
+		return "", fmt.Errorf("the ACL rules hold a value which is not a string")
+	}
+
+	return value.AsString(), nil
+}
+
+// readACLPolicyRule returns the rule of resource and segment in rules, or false when there is none.
+func readACLPolicyRule(rules, resource, segment string) (aclPolicyRule, bool, error) {
+	rule := aclPolicyRule{
+		Resource: resource,
+		Segment:  segment,
+	}
+
+	file, err := parseACLRules(rules)
+
+	if err != nil {
+		return rule, false, err
+	}
+
+	if !aclRuleResources[resource] {
+		attribute := file.Body().GetAttribute(resource)
+
+		if attribute == nil {
+			return rule, false, nil
+		}
+
+		rule.Access, err = hclStringValue(attribute)
+
+		return rule, err == nil, err
+	}
+
+	block := file.Body().FirstMatchingBlock(resource, []string{segment})
+
+	if block == nil {
+		return rule, false, nil
+	}
+
+	if attribute := block.Body().GetAttribute("policy"); attribute != nil {
+		if rule.Access, err = hclStringValue(attribute); err != nil {
+			return rule, false, err
+		}
+	}
+
+	if attribute := block.Body().GetAttribute("intentions"); attribute != nil {
+		if rule.Intentions, err = hclStringValue(attribute); err != nil {
+			return rule, false, err
+		}
+	}
+
+	return rule, true, nil
+}
+
+// setACLPolicyRule adds rule to rules, or replaces the rule of the same
+// resource and segment, keeping the other rules as written.
+func setACLPolicyRule(rules string, rule aclPolicyRule) (string, error) {
+	file, err := parseACLRules(rules)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !aclRuleResources[rule.Resource] {
+		file.Body().SetAttributeValue(rule.Resource, cty.StringVal(rule.Access))
+
+		return string(file.Bytes()), nil
+	}
+
+	block := file.Body().FirstMatchingBlock(rule.Resource, []string{rule.Segment})
+
+	if block == nil {
+		if len(file.Body().Attributes()) != 0 || len(file.Body().Blocks()) != 0 {
+			file.Body().AppendNewline()
+		}
+
+		block = file.Body().AppendNewBlock(rule.Resource, []string{rule.Segment})
+	}
+
+	block.Body().SetAttributeValue("policy", cty.StringVal(rule.Access))
+
+	if rule.Intentions == "" {
+		block.Body().RemoveAttribute("intentions")
+	} else {
+		block.Body().SetAttributeValue("intentions", cty.StringVal(rule.Intentions))
+	}
+
+	return string(file.Bytes()), nil
+}
+
+// removeACLPolicyRule removes the rule of resource and segment from rules,
+// keeping the other rules as written.
+func removeACLPolicyRule(rules, resource, segment string) (string, error) {
+	file, err := parseACLRules(rules)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !aclRuleResources[resource] {
+		file.Body().RemoveAttribute(resource)
+	} else if block := file.Body().FirstMatchingBlock(resource, []string{segment}); block != nil {
+		file.Body().RemoveBlock(block)
+	}
+
+	return strings.TrimRight(string(file.Bytes()), "\n") + "\n", nil
+}
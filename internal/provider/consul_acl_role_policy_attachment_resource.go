@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulACLRolePolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &ConsulACLRolePolicyAttachmentResource{}
+
+func NewConsulACLRolePolicyAttachmentResource() resource.Resource {
+	return &ConsulACLRolePolicyAttachmentResource{}
+}
+
+// ConsulACLRolePolicyAttachmentResource defines the resource implementation.
+type ConsulACLRolePolicyAttachmentResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulACLRolePolicyAttachmentResourceModel describes the resource data model.
+type ConsulACLRolePolicyAttachmentResourceModel struct {
+	Cluster  types.String `tfsdk:"cluster"`
+	Role     types.String `tfsdk:"role"`
+	Policy   types.String `tfsdk:"policy"`
+	PolicyId types.String `tfsdk:"policy_id"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_acl_role_policy_attachment"
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Attaches a single policy to an existing ACL role, keeping the other policies and identities of the role.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The name or the ID of the ACL role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "The name or the ID of the ACL policy attached to the role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the ACL policy attached to the role",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ACL role policy attachment identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (data ConsulACLRolePolicyAttachmentResourceModel) attach(role *api.ACLRole) error {
+	if findRolePolicy(role, data.PolicyId.ValueString()) == -1 {
+		role.Policies = append(role.Policies, &api.ACLRolePolicyLink{
+			ID: data.PolicyId.ValueString(),
+		})
+	}
+
+	return nil
+}
+
+func (data ConsulACLRolePolicyAttachmentResourceModel) detach(role *api.ACLRole) error {
+	if i := findRolePolicy(role, data.PolicyId.ValueString()); i != -1 {
+		role.Policies = append(role.Policies[:i], role.Policies[i+1:]...)
+	}
+
+	return nil
+}
+
+func (data ConsulACLRolePolicyAttachmentResourceModel) id() string {
+	return fmt.Sprintf("%s_%s", data.Role.ValueString(), data.Policy.ValueString())
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulACLRolePolicyAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	policy, err := readACLPolicy(client, data.Policy.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL policy, got error: %s", err))
+		return
+	}
+
+	if policy == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ACL policy %q does not exist", data.Policy.ValueString()))
+		return
+	}
+
+	data.PolicyId = types.StringValue(policy.ID)
+
+	err = updateACLRole(client, data.Role.ValueString(), data.attach)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL role, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "acl role policy attachment")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulACLRolePolicyAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	// The ID of the policy is not known yet when the attachment is imported
+	if data.PolicyId.IsNull() {
+		policy, err := readACLPolicy(client, data.Policy.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL policy, got error: %s", err))
+			return
+		}
+
+		if policy == nil {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		data.PolicyId = types.StringValue(policy.ID)
+	}
+
+	role, err := readACLRole(client, data.Role.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL role, got error: %s", err))
+		return
+	}
+
+	if role == nil || findRolePolicy(role, data.PolicyId.ValueString()) == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulACLRolePolicyAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every attribute requires a replacement, so there is nothing to update
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulACLRolePolicyAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	role, err := readACLRole(client, data.Role.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL role, got error: %s", err))
+		return
+	}
+
+	// Nothing to detach from a deleted role
+	if role != nil {
+		err = updateACLRole(client, role.ID, data.detach)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL role, got error: %s", err))
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulACLRolePolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromID(ctx, req, resp, []string{"role", "policy"})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccCreateACLRole creates the ACL role the links of the tests are added to.
+func testAccCreateACLRole(t *testing.T, name string) {
+	client, err := api.NewClient(api.DefaultConfig())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if role, err := readACLRole(client, name); err != nil || role != nil {
+		return
+	}
+
+	if _, _, err := client.ACL().RoleCreate(&api.ACLRole{Name: name}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAccConsulACLRolePolicyAttachmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckACL(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: func() {
+					testAccCreateACLRole(t, "utils-test")
+					testAccCreateACLPolicy(t, "utils-test")
+				},
+				Config: `
+resource "utils_consul_acl_role_policy_attachment" "test" {
+	role   = "utils-test"
+	policy = "utils-test"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("utils_consul_acl_role_policy_attachment.test", "policy_id"),
+					resource.TestCheckResourceAttr("utils_consul_acl_role_policy_attachment.test", "id", "utils-test_utils-test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_acl_role_policy_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestACLRoleLinks(t *testing.T) {
+	role := &api.ACLRole{
+		Policies:          []*api.ACLRolePolicyLink{{ID: "other"}},
+		ServiceIdentities: []*api.ACLServiceIdentity{{ServiceName: "db"}},
+	}
+
+	attachment := ConsulACLRolePolicyAttachmentResourceModel{PolicyId: types.StringValue("policy")}
+
+	// Attaching twice adds a single link
+	for i := 0; i < 2; i++ {
+		if err := attachment.attach(role); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(role.Policies) != 2 || findRolePolicy(role, "policy") != 1 {
+		t.Fatalf("expected the policy to be linked once, got %+v", role.Policies)
+	}
+
+	identity := ConsulACLRoleServiceIdentityResourceModel{
+		ServiceName: types.StringValue("web"),
+		Datacenters: []types.String{types.StringValue("dc1")},
+	}
+
+	if err := identity.setIdentity(role); err != nil {
+		t.Fatal(err)
+	}
+
+	identity.Datacenters = []types.String{types.StringValue("dc2")}
+
+	if err := identity.setIdentity(role); err != nil {
+		t.Fatal(err)
+	}
+
+	if i := findRoleServiceIdentity(role, "web"); len(role.ServiceIdentities) != 2 || i == -1 || role.ServiceIdentities[i].Datacenters[0] != "dc2" {
+		t.Fatalf("expected the service identity to be replaced, got %+v", role.ServiceIdentities)
+	}
+
+	if err := attachment.detach(role); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := identity.removeIdentity(role); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(role.Policies) != 1 || role.Policies[0].ID != "other" {
+		t.Errorf("expected only the other policy to be kept, got %+v", role.Policies)
+	}
+
+	if len(role.ServiceIdentities) != 1 || role.ServiceIdentities[0].ServiceName != "db" {
+		t.Errorf("expected only the other service identity to be kept, got %+v", role.ServiceIdentities)
+	}
+}
+
+func TestConsulACLRolePolicyAttachmentResourceImportState(t *testing.T) {
+	testImportState(t, &ConsulACLRolePolicyAttachmentResource{}, "utils-test_global-management", map[string]attr.Value{
+		"role":      types.StringValue("utils-test"),
+		"policy":    types.StringValue("global-management"),
+		"policy_id": types.StringNull(),
+		"id":        types.StringValue("utils-test_global-management"),
+	})
+
+	testImportState(t, &ConsulACLRolePolicyAttachmentResource{}, "utils-test", nil)
+}
@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulACLRoleServiceIdentityResource{}
+var _ resource.ResourceWithImportState = &ConsulACLRoleServiceIdentityResource{}
+
+func NewConsulACLRoleServiceIdentityResource() resource.Resource {
+	return &ConsulACLRoleServiceIdentityResource{}
+}
+
+// ConsulACLRoleServiceIdentityResource defines the resource implementation.
+type ConsulACLRoleServiceIdentityResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulACLRoleServiceIdentityResourceModel describes the resource data model.
+type ConsulACLRoleServiceIdentityResourceModel struct {
+	Cluster     types.String   `tfsdk:"cluster"`
+	Role        types.String   `tfsdk:"role"`
+	ServiceName types.String   `tfsdk:"service_name"`
+	Datacenters []types.String `tfsdk:"datacenters"`
+	Id          types.String   `tfsdk:"id"`
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_acl_role_service_identity"
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Adds a single service identity to an existing ACL role, keeping the other policies and identities of the role.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The name or the ID of the ACL role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the service of the identity",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"datacenters": schema.ListAttribute{
+				MarkdownDescription: "The datacenters the identity is valid in. The identity is valid in every datacenter when unset.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ACL role service identity identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// setIdentity adds the service identity to the role, or replaces the one of the same service.
+func (data ConsulACLRoleServiceIdentityResourceModel) setIdentity(role *api.ACLRole) error {
+	identity := &api.ACLServiceIdentity{
+		ServiceName: data.ServiceName.ValueString(),
+	}
+
+	for _, datacenter := range data.Datacenters {
+		identity.Datacenters = append(identity.Datacenters, datacenter.ValueString())
+	}
+
+	if i := findRoleServiceIdentity(role, data.ServiceName.ValueString()); i != -1 {
+		role.ServiceIdentities[i] = identity
+	} else {
+		role.ServiceIdentities = append(role.ServiceIdentities, identity)
+	}
+
+	return nil
+}
+
+func (data ConsulACLRoleServiceIdentityResourceModel) removeIdentity(role *api.ACLRole) error {
+	if i := findRoleServiceIdentity(role, data.ServiceName.ValueString()); i != -1 {
+		role.ServiceIdentities = append(role.ServiceIdentities[:i], role.ServiceIdentities[i+1:]...)
+	}
+
+	return nil
+}
+
+func (data ConsulACLRoleServiceIdentityResourceModel) id() string {
+	return fmt.Sprintf("%s_%s", data.Role.ValueString(), data.ServiceName.ValueString())
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulACLRoleServiceIdentityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateACLRole(client, data.Role.ValueString(), data.setIdentity)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL role, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "acl role service identity")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulACLRoleServiceIdentityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	role, err := readACLRole(client, data.Role.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL role, got error: %s", err))
+		return
+	}
+
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	i := findRoleServiceIdentity(role, data.ServiceName.ValueString())
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// An empty list of datacenters is kept as configured
+	if datacenters := role.ServiceIdentities[i].Datacenters; len(datacenters) != 0 || len(data.Datacenters) != 0 {
+		data.Datacenters = make([]types.String, 0, len(datacenters))
+
+		for _, datacenter := range datacenters {
+			data.Datacenters = append(data.Datacenters, types.StringValue(datacenter))
+		}
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulACLRoleServiceIdentityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateACLRole(client, data.Role.ValueString(), data.setIdentity)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL role, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "acl role service identity")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulACLRoleServiceIdentityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	role, err := readACLRole(client, data.Role.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL role, got error: %s", err))
+		return
+	}
+
+	// Nothing to remove from a deleted role
+	if role != nil {
+		err = updateACLRole(client, role.ID, data.removeIdentity)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ACL role, got error: %s", err))
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulACLRoleServiceIdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromID(ctx, req, resp, []string{"role", "service_name"})
+}
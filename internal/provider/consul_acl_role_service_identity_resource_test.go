@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulACLRoleServiceIdentityResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckACL(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: func() { testAccCreateACLRole(t, "utils-test") },
+				Config: `
+resource "utils_consul_acl_role_service_identity" "test" {
+	role         = "utils-test"
+	service_name = "web"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("utils_consul_acl_role_service_identity.test", "datacenters"),
+					resource.TestCheckResourceAttr("utils_consul_acl_role_service_identity.test", "id", "utils-test_web"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_acl_role_service_identity" "test" {
+	role         = "utils-test"
+	service_name = "web"
+	datacenters  = ["dc1"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_acl_role_service_identity.test", "datacenters.#", "1"),
+					resource.TestCheckResourceAttr("utils_consul_acl_role_service_identity.test", "datacenters.0", "dc1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_acl_role_service_identity.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestConsulACLRoleServiceIdentityResourceImportState(t *testing.T) {
+	testImportState(t, &ConsulACLRoleServiceIdentityResource{}, "utils-test_web", map[string]attr.Value{
+		"role":         types.StringValue("utils-test"),
+		"service_name": types.StringValue("web"),
+		"id":           types.StringValue("utils-test_web"),
+	})
+
+	testImportState(t, &ConsulACLRoleServiceIdentityResource{}, "utils-test_web_dc1", nil)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultRouteStatusTimeout = "30s"
+
+// Interval between two reads of a route while waiting for consul to accept it
+const routeStatusPollInterval = 2 * time.Second
+
+// Time after which the conditions from before a write are trusted, as consul
+// leaves them untouched when the write does not change the status of the route
+const routeStatusSettleTime = 10 * time.Second
+
+// routeParents returns the parents of an http-route or tcp-route entry.
+func routeParents(configEntry api.ConfigEntry) (*[]api.ResourceReference, error) {
+	switch route := configEntry.(type) {
+	case *api.HTTPRouteConfigEntry:
+		return &route.Parents, nil
+	case *api.TCPRouteConfigEntry:
+		return &route.Parents, nil
+	}
+
+	return nil, fmt.Errorf("config entry %s/%s is not a route", configEntry.GetKind(), configEntry.GetName())
+}
+
+// routeStatus returns the status consul reports for an http-route or tcp-route entry.
+func routeStatus(configEntry api.ConfigEntry) api.ConfigEntryStatus {
+	switch route := configEntry.(type) {
+	case *api.HTTPRouteConfigEntry:
+		return route.Status
+	case *api.TCPRouteConfigEntry:
+		return route.Status
+	}
+
+	return api.ConfigEntryStatus{}
+}
+
+// findRouteParent returns the index of parent in parents, or -1 when there is none.
+func findRouteParent(parents []api.ResourceReference, parent api.ResourceReference) int {
+	for i, other := range parents {
+		if other == parent {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// routeConditions returns the conditions telling whether the route is
+// accepted and, when parent is set, bound to it, and whether they all hold.
+func routeConditions(status api.ConfigEntryStatus, parent *api.ResourceReference) ([]api.Condition, bool) {
+	var conditions []api.Condition
+
+	accepted, bound := false, parent == nil
+
+	for _, condition := range status.Conditions {
+		switch {
+		case condition.Type == string(api.RouteConditionAccepted):
+			accepted = condition.Status == api.ConditionStatusTrue
+		case condition.Type == string(api.RouteConditionBound) && parent != nil && condition.Resource != nil &&
+			condition.Resource.Name == parent.Name && condition.Resource.SectionName == parent.SectionName:
+			bound = condition.Status == api.ConditionStatusTrue
+		default:
+			continue
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, accepted && bound
+}
+
+// routeStatusChangedSince tells whether consul changed a condition of the
+// route since written, and so computed the status of the route as written.
+func routeStatusChangedSince(status api.ConfigEntryStatus, written time.Time) bool {
+	for _, condition := range status.Conditions {
+		if condition.LastTransitionTime != nil && !condition.LastTransitionTime.Before(written) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitForRouteStatus polls the route written at the given time until consul
+// reports it as accepted and, when parent is set, bound to it, or the timeout
+// expires. The conditions from before the write are only trusted once the
+// route had time to settle. It returns the last conditions of the route, to
+// be reported when they do not hold.
+func waitForRouteStatus(ctx context.Context, client *api.Client, kind, name string, parent *api.ResourceReference, written time.Time, timeout time.Duration) ([]api.Condition, error) {
+	expected := "accepted"
+
+	if parent != nil {
+		expected = fmt.Sprintf("accepted and bound to %s %q", parent.Kind, parent.Name)
+	}
+
+	deadline := time.Now().Add(timeout)
+	settled := written.Add(min(routeStatusSettleTime, timeout))
+
+	for {
+		configEntry, err := readConfigEntry(client, kind, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if configEntry == nil {
+			return nil, fmt.Errorf("%s %q was deleted while waiting for it to be %s", kind, name, expected)
+		}
+
+		status := routeStatus(configEntry)
+		conditions, ok := routeConditions(status, parent)
+
+		if ok && (routeStatusChangedSince(status, written) || !time.Now().Before(settled)) {
+			return conditions, nil
+		}
+
+		if time.Now().After(deadline) {
+			return conditions, fmt.Errorf("timed out after %s waiting for %s %q to be %s", timeout, kind, name, expected)
+		}
+
+		tflog.Debug(ctx, "waiting for route status", map[string]interface{}{
+			"kind": kind,
+			"name": name,
+		})
+
+		select {
+		case <-ctx.Done():
+			return conditions, ctx.Err()
+		case <-time.After(routeStatusPollInterval):
+		}
+	}
+}
+
+// addRouteStatusDiagnostics reports err along with the conditions of the route which do not hold.
+func addRouteStatusDiagnostics(diagnostics *diag.Diagnostics, conditions []api.Condition, err error) {
+	for _, condition := range conditions {
+		if condition.Status != api.ConditionStatusTrue {
+			diagnostics.AddError(fmt.Sprintf("Route Not %s", condition.Type), fmt.Sprintf("%s: %s", condition.Reason, condition.Message))
+		}
+	}
+
+	diagnostics.AddError("Route Status Error", fmt.Sprintf("Unable to confirm the route status, got error: %s", err))
+}
+
+// httpRouteRuleFingerprint identifies a rule of an http-route entry by its matches.
+func httpRouteRuleFingerprint(matches []api.HTTPMatch) string {
+	encoded, _ := json.Marshal(matches)
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// findHTTPRouteRule returns the index of the rule with the fingerprint, or -1 when there is none.
+func findHTTPRouteRule(configEntry *api.HTTPRouteConfigEntry, fingerprint string) int {
+	for i, rule := range configEntry.Rules {
+		if httpRouteRuleFingerprint(rule.Matches) == fingerprint {
+			return i
+		}
+	}
+
+	return -1
+}
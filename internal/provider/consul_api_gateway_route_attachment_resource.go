@@ -0,0 +1,407 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulAPIGatewayRouteAttachmentResource{}
+var _ resource.ResourceWithImportState = &ConsulAPIGatewayRouteAttachmentResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulAPIGatewayRouteAttachmentResource{}
+
+func NewConsulAPIGatewayRouteAttachmentResource() resource.Resource {
+	return &ConsulAPIGatewayRouteAttachmentResource{}
+}
+
+// ConsulAPIGatewayRouteAttachmentResource defines the resource implementation.
+type ConsulAPIGatewayRouteAttachmentResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulAPIGatewayRouteAttachmentResourceModel describes the resource data model.
+type ConsulAPIGatewayRouteAttachmentResourceModel struct {
+	Cluster       types.String `tfsdk:"cluster"`
+	RouteKind     types.String `tfsdk:"route_kind"`
+	Route         types.String `tfsdk:"route"`
+	Gateway       types.String `tfsdk:"gateway"`
+	Listener      types.String `tfsdk:"listener"`
+	StatusTimeout types.String `tfsdk:"status_timeout"`
+	Id            types.String `tfsdk:"id"`
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_api_gateway_route_attachment"
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Attaches an existing `http-route` or `tcp-route` config entry to a listener of an `api-gateway`, then waits until consul reports the route as accepted and bound to it. The conditions reported before the write are only trusted after " + routeStatusSettleTime.String() + ", the time for consul to update them. Creating the resource fails when the route is already attached to the listener.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"route_kind": schema.StringAttribute{
+				MarkdownDescription: "The kind of the route, either `http-route` or `tcp-route`. Defaults to `http-route`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(api.HTTPRoute),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"route": schema.StringAttribute{
+				MarkdownDescription: "The name of the route",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				MarkdownDescription: "The name of the API gateway",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"listener": schema.StringAttribute{
+				MarkdownDescription: "The name of the listener of the gateway. The route is attached to every listener of the gateway when unset.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for consul to report the route as accepted and bound, as a Go duration string. Defaults to `" + defaultRouteStatusTimeout + "`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultRouteStatusTimeout),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "API gateway route attachment identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConsulAPIGatewayRouteAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if kind := data.RouteKind.ValueString(); kind != "" && kind != api.HTTPRoute && kind != api.TCPRoute {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("route_kind"),
+			"Invalid Route Kind",
+			fmt.Sprintf("The route kind must be either %q or %q, got %q.", api.HTTPRoute, api.TCPRoute, kind),
+		)
+	}
+
+	if _, err := parseOptionalDuration("status_timeout", data.StatusTimeout); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("status_timeout"), "Invalid Status Timeout", err.Error())
+	}
+}
+
+func (data ConsulAPIGatewayRouteAttachmentResourceModel) parent() api.ResourceReference {
+	return api.ResourceReference{
+		Kind:        api.APIGateway,
+		Name:        data.Gateway.ValueString(),
+		SectionName: data.Listener.ValueString(),
+	}
+}
+
+func (data ConsulAPIGatewayRouteAttachmentResourceModel) id() string {
+	if data.Listener.IsNull() {
+		return fmt.Sprintf("%s_%s", data.Route.ValueString(), data.Gateway.ValueString())
+	}
+
+	return fmt.Sprintf("%s_%s_%s", data.Route.ValueString(), data.Gateway.ValueString(), data.Listener.ValueString())
+}
+
+// attach adds the gateway listener to the parents of the route, which must exist.
+func (data ConsulAPIGatewayRouteAttachmentResourceModel) attach(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	if configEntry == nil {
+		return nil, fmt.Errorf("%s %q does not exist", data.RouteKind.ValueString(), data.Route.ValueString())
+	}
+
+	parents, err := routeParents(configEntry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if findRouteParent(*parents, data.parent()) == -1 {
+		*parents = append(*parents, data.parent())
+	}
+
+	return configEntry, nil
+}
+
+// attachNew adds the gateway listener to the parents of the route, refusing
+// the one already there, as it belongs to another owner.
+func (data ConsulAPIGatewayRouteAttachmentResourceModel) attachNew(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	if configEntry != nil {
+		parents, err := routeParents(configEntry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if findRouteParent(*parents, data.parent()) != -1 {
+			return nil, fmt.Errorf("%s %q is already attached to %s %q", data.RouteKind.ValueString(), data.Route.ValueString(), api.APIGateway, data.Gateway.ValueString())
+		}
+	}
+
+	return data.attach(configEntry)
+}
+
+// detach removes the gateway listener from the parents of the route.
+func (data ConsulAPIGatewayRouteAttachmentResourceModel) detach(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	// Nothing to detach from a deleted route
+	if configEntry == nil {
+		return nil, nil
+	}
+
+	parents, err := routeParents(configEntry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if i := findRouteParent(*parents, data.parent()); i != -1 {
+		*parents = append((*parents)[:i], (*parents)[i+1:]...)
+	}
+
+	return configEntry, nil
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulAPIGatewayRouteAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	timeout, err := parseOptionalDuration("status_timeout", data.StatusTimeout)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Status Timeout", err.Error())
+		return
+	}
+
+	written := time.Now()
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, data.RouteKind.ValueString(), data.Route.ValueString(), data.attachNew)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write route, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "api gateway route attachment")
+
+	// The route is attached even when consul does not bind it, so it is kept in the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	parent := data.parent()
+	conditions, err := waitForRouteStatus(ctx, client, data.RouteKind.ValueString(), data.Route.ValueString(), &parent, written, timeout)
+
+	if err != nil {
+		addRouteStatusDiagnostics(&resp.Diagnostics, conditions, err)
+	}
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulAPIGatewayRouteAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, err := readConfigEntry(client, data.RouteKind.ValueString(), data.Route.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read route, got error: %s", err))
+		return
+	}
+
+	if configEntry == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	parents, err := routeParents(configEntry)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read route, got error: %s", err))
+		return
+	}
+
+	if findRouteParent(*parents, data.parent()) == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulAPIGatewayRouteAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	timeout, err := parseOptionalDuration("status_timeout", data.StatusTimeout)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Status Timeout", err.Error())
+		return
+	}
+
+	written := time.Now()
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, data.RouteKind.ValueString(), data.Route.ValueString(), data.attach)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write route, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "api gateway route attachment")
+
+	// The route is attached even when consul does not bind it, so it is kept in the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	parent := data.parent()
+	conditions, err := waitForRouteStatus(ctx, client, data.RouteKind.ValueString(), data.Route.ValueString(), &parent, written, timeout)
+
+	if err != nil {
+		addRouteStatusDiagnostics(&resp.Diagnostics, conditions, err)
+	}
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulAPIGatewayRouteAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, data.RouteKind.ValueString(), data.Route.ValueString(), data.detach)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write route, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulAPIGatewayRouteAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	routeKind := api.HTTPRoute
+
+	// The ID of a TCP route is prefixed by its kind when imported, such as tcp-route_db_gateway
+	if kind, id, ok := strings.Cut(req.ID, "_"); ok && (kind == api.HTTPRoute || kind == api.TCPRoute) {
+		routeKind = kind
+		req.ID = id
+	}
+
+	importStateFromID(ctx, req, resp, []string{"route", "gateway"}, []string{"route", "gateway", "listener"})
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("route_kind"), routeKind)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status_timeout"), defaultRouteStatusTimeout)...)
+}
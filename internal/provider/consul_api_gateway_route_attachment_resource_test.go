@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccConsulAPIGatewayRouteAttachmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: func() {
+					testAccWriteConfigEntries(t,
+						&api.ServiceConfigEntry{Kind: api.ServiceDefaults, Name: "web", Protocol: "http"},
+						&api.APIGatewayConfigEntry{
+							Kind:      api.APIGateway,
+							Name:      "api-gateway",
+							Listeners: []api.APIGatewayListener{{Name: "http", Port: 8080, Protocol: "http"}},
+						},
+						&api.HTTPRouteConfigEntry{
+							Kind:  api.HTTPRoute,
+							Name:  "web",
+							Rules: []api.HTTPRouteRule{{Services: []api.HTTPService{{Name: "web"}}}},
+						},
+					)
+				},
+				Config: `
+resource "utils_consul_api_gateway_route_attachment" "test" {
+	route    = "web"
+	gateway  = "api-gateway"
+	listener = "http"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_api_gateway_route_attachment.test", "route_kind", "http-route"),
+					resource.TestCheckResourceAttr("utils_consul_api_gateway_route_attachment.test", "id", "web_api-gateway_http"),
+					testAccCheckRouteParent("web", api.ResourceReference{Kind: api.APIGateway, Name: "api-gateway", SectionName: "http"}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_api_gateway_route_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+// testAccCheckRouteParent checks that the http-route is attached to the parent.
+func testAccCheckRouteParent(route string, parent api.ResourceReference) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client, err := api.NewClient(api.DefaultConfig())
+
+		if err != nil {
+			return err
+		}
+
+		configEntry, err := readConfigEntry(client, api.HTTPRoute, route)
+
+		if err != nil {
+			return err
+		}
+
+		if configEntry == nil {
+			return fmt.Errorf("%s %q does not exist", api.HTTPRoute, route)
+		}
+
+		parents, err := routeParents(configEntry)
+
+		if err != nil {
+			return err
+		}
+
+		if findRouteParent(*parents, parent) < 0 {
+			return fmt.Errorf("expected %s %q to be attached to %+v, got parents %+v", api.HTTPRoute, route, parent, *parents)
+		}
+
+		return nil
+	}
+}
+
+func TestWaitForRouteStatus(t *testing.T) {
+	client, _ := newTestConfigEntryClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.ConfigEntries().Set(&api.HTTPRouteConfigEntry{
+		Kind: api.HTTPRoute,
+		Name: "web",
+		Status: api.ConfigEntryStatus{
+			Conditions: []api.Condition{
+				{Type: string(api.RouteConditionAccepted), Status: api.ConditionStatusTrue, Reason: "Accepted"},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := ConsulAPIGatewayRouteAttachmentResourceModel{
+		RouteKind: types.StringValue(api.HTTPRoute),
+		Route:     types.StringValue("web"),
+		Gateway:   types.StringValue("api-gateway"),
+		Listener:  types.StringValue("http"),
+	}
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", data.attachNew); err != nil {
+		t.Fatal(err)
+	}
+
+	// The attachment of another owner is refused on create, and kept on update
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", data.attachNew); err == nil {
+		t.Error("expected an error when attaching the route to the same gateway listener again")
+	}
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", data.attach); err != nil {
+		t.Fatal(err)
+	}
+
+	route, _, err := readTypedConfigEntry[*api.HTTPRouteConfigEntry](client, api.HTTPRoute, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(route.Parents) != 1 || route.Parents[0] != data.parent() {
+		t.Fatalf("expected the gateway listener to be attached once, got %+v", route.Parents)
+	}
+
+	parent := data.parent()
+
+	// The route is accepted, but not bound yet
+	conditions, err := waitForRouteStatus(ctx, client, api.HTTPRoute, "web", &parent, time.Now(), 0)
+
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+
+	if len(conditions) != 1 {
+		t.Errorf("expected the accepted condition to be reported, got %+v", conditions)
+	}
+
+	if _, err := waitForRouteStatus(ctx, client, api.HTTPRoute, "web", nil, time.Now(), 0); err != nil {
+		t.Errorf("expected the route to be accepted, got %v", err)
+	}
+
+	route.Status.Conditions = append(route.Status.Conditions, api.Condition{
+		Type:     string(api.RouteConditionBound),
+		Status:   api.ConditionStatusTrue,
+		Resource: &parent,
+	})
+
+	if _, _, err := client.ConfigEntries().Set(route, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := waitForRouteStatus(ctx, client, api.HTTPRoute, "web", &parent, time.Now(), 0); err != nil {
+		t.Errorf("expected the route to be bound, got %v", err)
+	}
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", data.detach); err != nil {
+		t.Fatal(err)
+	}
+
+	route, _, err = readTypedConfigEntry[*api.HTTPRouteConfigEntry](client, api.HTTPRoute, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(route.Parents) != 0 {
+		t.Errorf("expected the gateway listener to be detached, got %+v", route.Parents)
+	}
+
+	missing := data
+	missing.Route = types.StringValue("missing")
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "missing", missing.attachNew); err == nil {
+		t.Error("expected an error when attaching a route which does not exist")
+	}
+}
+
+func TestRouteStatusChangedSince(t *testing.T) {
+	written := time.Now()
+	before, after := written.Add(-time.Minute), written.Add(time.Second)
+
+	status := api.ConfigEntryStatus{
+		Conditions: []api.Condition{
+			{Type: string(api.RouteConditionAccepted), Status: api.ConditionStatusTrue, LastTransitionTime: &before},
+			{Type: string(api.RouteConditionBound), Status: api.ConditionStatusTrue},
+		},
+	}
+
+	// The accepted condition from before the write does not tell whether consul accepts the route as written
+	if routeStatusChangedSince(status, written) {
+		t.Error("expected the conditions from before the write not to reflect it")
+	}
+
+	status.Conditions[1].LastTransitionTime = &after
+
+	if !routeStatusChangedSince(status, written) {
+		t.Error("expected a condition changed after the write to reflect it")
+	}
+}
+
+func TestConsulAPIGatewayRouteAttachmentResourceImportState(t *testing.T) {
+	r := &ConsulAPIGatewayRouteAttachmentResource{}
+
+	testImportState(t, r, "web_api-gateway_http", map[string]attr.Value{
+		"route_kind":     types.StringValue(api.HTTPRoute),
+		"route":          types.StringValue("web"),
+		"gateway":        types.StringValue("api-gateway"),
+		"listener":       types.StringValue("http"),
+		"status_timeout": types.StringValue(defaultRouteStatusTimeout),
+		"id":             types.StringValue("web_api-gateway_http"),
+	})
+
+	testImportState(t, r, "tcp-route_db_api-gateway", map[string]attr.Value{
+		"route_kind": types.StringValue(api.TCPRoute),
+		"route":      types.StringValue("db"),
+		"gateway":    types.StringValue("api-gateway"),
+		"listener":   types.StringNull(),
+		"id":         types.StringValue("db_api-gateway"),
+	})
+
+	for _, id := range []string{"web", "tcp-route_db", "web_api-gateway_http_other"} {
+		testImportState(t, r, id, nil)
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// MissingCredentialsError is returned when no usable consul token is configured.
+type MissingCredentialsError struct {
+	Reason string
+	Err    error
+}
+
+func (e *MissingCredentialsError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s", e.Reason, e.Err)
+	}
+
+	return e.Reason
+}
+
+func (e *MissingCredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidJWTError is returned when the consul token is neither a secret ID nor
+// a well-formed, unexpired JWT.
+type InvalidJWTError struct {
+	Reason string
+}
+
+func (e *InvalidJWTError) Error() string {
+	return fmt.Sprintf("invalid JWT: %s", e.Reason)
+}
+
+// LoginError is returned when logging in to the auth method fails.
+type LoginError struct {
+	AuthMethod string
+	Err        error
+}
+
+func (e *LoginError) Error() string {
+	return fmt.Sprintf("unable to log in to auth method %q: %s", e.AuthMethod, e.Err)
+}
+
+func (e *LoginError) Unwrap() error {
+	return e.Err
+}
+
+// PermissionDeniedError is returned when Consul rejects the credentials.
+type PermissionDeniedError struct {
+	AuthMethod string
+	Err        error
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("permission denied by auth method %q: %s", e.AuthMethod, e.Err)
+}
+
+func (e *PermissionDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// addAuthenticationError adds a diagnostic explaining how to fix err.
+func addAuthenticationError(diagnostics *diag.Diagnostics, err error) {
+	var missingCredentialsError *MissingCredentialsError
+	var invalidJWTError *InvalidJWTError
+	var loginError *LoginError
+	var permissionDeniedError *PermissionDeniedError
+
+	switch {
+	case errors.As(err, &missingCredentialsError):
+		diagnostics.AddError(
+			"Missing Consul Credentials",
+			fmt.Sprintf("Unable to find a usable consul token: %s.\n\n"+
+				"Set one of the consul_token, consul_token_file or consul_token_command provider attributes, "+
+				"or the CONSUL_HTTP_TOKEN or CONSUL_HTTP_TOKEN_FILE environment variables. "+
+				"JWT tokens also require acl_auth_method to be set.", err),
+		)
+	case errors.As(err, &invalidJWTError):
+		diagnostics.AddError(
+			"Invalid Consul Token",
+			fmt.Sprintf("The consul token is not a UUID secret ID, so it must be a JWT, but %s.\n\n"+
+				"Check that the token source returns the token itself, without any prefix, "+
+				"and fetch a new token if it has expired.", invalidJWTError.Reason),
+		)
+	case errors.As(err, &permissionDeniedError):
+		diagnostics.AddError(
+			"Consul Permission Denied",
+			fmt.Sprintf("Consul rejected the JWT when logging in to auth method %q: %s.\n\n"+
+				"Check that the auth method trusts the issuer of the JWT, and that a binding rule "+
+				"matches its claims.", permissionDeniedError.AuthMethod, permissionDeniedError.Err),
+		)
+	case errors.As(err, &loginError):
+		diagnostics.AddError(
+			"Consul Login Failed",
+			fmt.Sprintf("Unable to log in to auth method %q: %s.\n\n"+
+				"Check that the auth method exists in the configured namespace and partition, "+
+				"and that the consul cluster is reachable.", loginError.AuthMethod, loginError.Err),
+		)
+	default:
+		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to authenticate to consul, got error: %s", err))
+	}
+}
+
+// isACLEnabled tells whether a token must be sent to the cluster, looking at
+// the acl_enabled attribute, then at the CONSUL_ACL_ENABLED environment variable.
+func isACLEnabled(clusterModel ConsulClusterModel) bool {
+	if !clusterModel.AclEnabled.IsNull() {
+		return clusterModel.AclEnabled.ValueBool()
+	}
+
+	enabled, err := strconv.ParseBool(clusterModel.getenv("CONSUL_ACL_ENABLED"))
+
+	return err != nil || enabled
+}
+
+func readConsulTokenFile(tokenFile string) (string, error) {
+	content, err := os.ReadFile(tokenFile)
+
+	if err != nil {
+		return "", &MissingCredentialsError{
+			Reason: fmt.Sprintf("unable to read consul token file %q", tokenFile),
+			Err:    err,
+		}
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// Time given to consul_token_command to print the token, so that a hanging
+// command does not block the provider configuration
+var consulTokenCommandTimeout = 30 * time.Second
+
+func runConsulTokenCommand(tokenCommand []types.String) (string, error) {
+	args := make([]string, 0, len(tokenCommand))
+
+	for _, arg := range tokenCommand {
+		args = append(args, arg.ValueString())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consulTokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Children of the command keeping its output open must not block it either
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+
+	if ctx.Err() != nil {
+		err = fmt.Errorf("timed out after %s", consulTokenCommandTimeout)
+	}
+
+	if err != nil {
+		return "", &MissingCredentialsError{
+			Reason: fmt.Sprintf("unable to run consul token command %q", args[0]),
+			Err:    fmt.Errorf("%w, stderr: %s", err, strings.TrimSpace(stderr.String())),
+		}
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// resolveConsulToken returns the initial token, looking in order at the
+// consul_token, consul_token_file and consul_token_command attributes, then at
+// the CONSUL_HTTP_TOKEN and CONSUL_HTTP_TOKEN_FILE environment variables.
+func resolveConsulToken(clusterModel ConsulClusterModel) (string, error) {
+	if !clusterModel.ConsulToken.IsNull() {
+		return clusterModel.ConsulToken.ValueString(), nil
+	}
+
+	if !clusterModel.ConsulTokenFile.IsNull() {
+		return readConsulTokenFile(clusterModel.ConsulTokenFile.ValueString())
+	}
+
+	if len(clusterModel.ConsulTokenCommand) > 0 {
+		return runConsulTokenCommand(clusterModel.ConsulTokenCommand)
+	}
+
+	if clusterModel.getenv("CONSUL_HTTP_TOKEN") != "" {
+		return clusterModel.getenv("CONSUL_HTTP_TOKEN"), nil
+	}
+
+	if clusterModel.getenv("CONSUL_HTTP_TOKEN_FILE") != "" {
+		return readConsulTokenFile(clusterModel.getenv("CONSUL_HTTP_TOKEN_FILE"))
+	}
+
+	return "", &MissingCredentialsError{
+		Reason: "no consul token is configured",
+	}
+}
+
+// validateJWT checks the structure and the validity period of a JWT. The
+// signature is left to Consul, which knows the keys of the auth method.
+func validateJWT(token string, now time.Time) error {
+	if token == "" {
+		return &InvalidJWTError{Reason: "the token is empty"}
+	}
+
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return &InvalidJWTError{Reason: fmt.Sprintf("the token has %d dot separated parts instead of 3", len(parts))}
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return &InvalidJWTError{Reason: fmt.Sprintf("its header cannot be decoded: %s", err)}
+	}
+
+	if header.Alg == "" || strings.EqualFold(header.Alg, "none") {
+		return &InvalidJWTError{Reason: "its header does not declare a signing algorithm"}
+	}
+
+	var claims struct {
+		ExpiresAt *json.Number `json:"exp"`
+		NotBefore *json.Number `json:"nbf"`
+	}
+
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return &InvalidJWTError{Reason: fmt.Sprintf("its claims cannot be decoded: %s", err)}
+	}
+
+	if claims.ExpiresAt != nil {
+		expiresAt, err := claims.ExpiresAt.Float64()
+
+		if err != nil {
+			return &InvalidJWTError{Reason: fmt.Sprintf("its exp claim %q is not a number", claims.ExpiresAt.String())}
+		}
+
+		if now.After(time.Unix(int64(expiresAt), 0)) {
+			return &InvalidJWTError{Reason: fmt.Sprintf("it expired at %s", time.Unix(int64(expiresAt), 0).UTC().Format(time.RFC3339))}
+		}
+	}
+
+	if claims.NotBefore != nil {
+		notBefore, err := claims.NotBefore.Float64()
+
+		if err != nil {
+			return &InvalidJWTError{Reason: fmt.Sprintf("its nbf claim %q is not a number", claims.NotBefore.String())}
+		}
+
+		if now.Before(time.Unix(int64(notBefore), 0)) {
+			return &InvalidJWTError{Reason: fmt.Sprintf("it is not valid before %s", time.Unix(int64(notBefore), 0).UTC().Format(time.RFC3339))}
+		}
+	}
+
+	return nil
+}
+
+func decodeJWTPart(part string, out interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.UseNumber()
+
+	return decoder.Decode(out)
+}
+
+// Tokens expiring in less than this are renewed before being used
+const consulTokenExpiryMargin = 30 * time.Second
+
+// consulLoginTokenSource logs in to an ACL auth method and logs in again when
+// the resulting token is about to expire, so long applies outlive the token.
+type consulLoginTokenSource struct {
+	client       *api.Client
+	clusterModel ConsulClusterModel
+	bearerToken  string
+
+	lock       sync.Mutex
+	secretID   string
+	expiration *time.Time
+}
+
+func newConsulLoginTokenSource(client *api.Client, clusterModel ConsulClusterModel, bearerToken string) *consulLoginTokenSource {
+	return &consulLoginTokenSource{
+		client:       client,
+		clusterModel: clusterModel,
+		bearerToken:  bearerToken,
+	}
+}
+
+func (s *consulLoginTokenSource) login(ctx context.Context) error {
+	err := validateJWT(s.bearerToken, time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	meta := make(map[string]string)
+
+	if !s.clusterModel.AclAuthMethodMeta.IsNull() {
+		diags := s.clusterModel.AclAuthMethodMeta.ElementsAs(ctx, &meta, false)
+
+		if diags.HasError() {
+			return fmt.Errorf("invalid acl_auth_method_meta")
+		}
+	}
+
+	token, _, err := s.client.ACL().Login(&api.ACLLoginParams{
+		AuthMethod:  s.clusterModel.AclAuthMethod.ValueString(),
+		BearerToken: s.bearerToken,
+		Meta:        meta,
+	}, &api.WriteOptions{
+		Namespace: s.clusterModel.AclAuthMethodNamespace.ValueString(),
+		Partition: s.clusterModel.AclAuthMethodPartition.ValueString(),
+	})
+
+	if err != nil {
+		var statusError api.StatusError
+
+		if errors.As(err, &statusError) && (statusError.Code == http.StatusForbidden || statusError.Code == http.StatusUnauthorized) {
+			return &PermissionDeniedError{AuthMethod: s.clusterModel.AclAuthMethod.ValueString(), Err: err}
+		}
+
+		return &LoginError{AuthMethod: s.clusterModel.AclAuthMethod.ValueString(), Err: err}
+	}
+
+	s.secretID = token.SecretID
+	s.expiration = token.ExpirationTime
+
+	tflog.Debug(ctx, "logged in to consul auth method", map[string]interface{}{
+		"auth_method": s.clusterModel.AclAuthMethod.ValueString(),
+		"accessor_id": token.AccessorID,
+	})
+
+	return nil
+}
+
+// Token returns the current ACL token, logging in again when it is about to expire.
+func (s *consulLoginTokenSource) Token(ctx context.Context) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.secretID != "" && (s.expiration == nil || time.Until(*s.expiration) > consulTokenExpiryMargin) {
+		return s.secretID, nil
+	}
+
+	if s.secretID != "" {
+		tflog.Info(ctx, "consul token is about to expire, logging in again", map[string]interface{}{
+			"expiration": s.expiration.String(),
+		})
+
+		// The bearer token may have been rotated since the last login, such as
+		// a projected workload identity JWT.
+		bearerToken, err := resolveConsulToken(s.clusterModel)
+
+		if err == nil {
+			s.bearerToken = bearerToken
+		}
+	}
+
+	err := s.login(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return s.secretID, nil
+}
+
+// consulTokenTransport sets the token of a consulLoginTokenSource on every
+// request sent to Consul.
+type consulTokenTransport struct {
+	base        http.RoundTripper
+	tokenSource *consulLoginTokenSource
+}
+
+func (t *consulTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokenSource.Token(req.Context())
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate to consul: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Consul-Token", token)
+
+	return t.base.RoundTrip(req)
+}
+
+// withConsulTokenSource returns a copy of httpClient authenticating its
+// requests with the tokens of tokenSource.
+func withConsulTokenSource(httpClient *http.Client, tokenSource *consulLoginTokenSource) *http.Client {
+	base := httpClient.Transport
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	authenticatedClient := *httpClient
+	authenticatedClient.Transport = &consulTokenTransport{
+		base:        base,
+		tokenSource: tokenSource,
+	}
+
+	return &authenticatedClient
+}
@@ -0,0 +1,350 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConsulLoginTokenSourceRenewsExpiringToken(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_TOKEN", "")
+	t.Setenv("CONSUL_HTTP_TOKEN_FILE", "")
+
+	var logins atomic.Int32
+	var lastLogin api.ACLLoginParams
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/acl/login":
+			if err := json.NewDecoder(r.Body).Decode(&lastLogin); err != nil {
+				t.Errorf("unable to decode login request: %s", err)
+			}
+
+			if r.URL.Query().Get("ns") != "ci" {
+				t.Errorf("expected login in namespace ci, got %q", r.URL.Query().Get("ns"))
+			}
+
+			count := logins.Add(1)
+			// The first token expires right away, the second one later
+			expiration := time.Now().Add(time.Duration(count-1) * time.Hour)
+
+			_ = json.NewEncoder(w).Encode(api.ACLToken{
+				SecretID:       fmt.Sprintf("00000000-0000-0000-0000-00000000000%d", count),
+				ExpirationTime: &expiration,
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": r.Header.Get("X-Consul-Token")})
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusterModel := ConsulClusterModel{
+		AclAuthMethod: types.StringValue("jwt"),
+		AclAuthMethodMeta: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"pipeline": types.StringValue("42"),
+		}),
+		AclAuthMethodNamespace: types.StringValue("ci"),
+	}
+
+	bearerToken := testJWT(t, map[string]interface{}{"sub": "ci", "exp": time.Now().Add(time.Hour).Unix()})
+
+	tokenSource := newConsulLoginTokenSource(client, clusterModel, bearerToken)
+	httpClient := withConsulTokenSource(http.DefaultClient, tokenSource)
+
+	for expected := 1; expected <= 2; expected++ {
+		resp, err := httpClient.Get(server.URL + "/v1/agent/self")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var body map[string]string
+
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		resp.Body.Close()
+
+		expectedToken := fmt.Sprintf("00000000-0000-0000-0000-00000000000%d", expected)
+
+		if body["token"] != expectedToken {
+			t.Errorf("expected request %d to use token %q, got %q", expected, expectedToken, body["token"])
+		}
+	}
+
+	// The second token is still valid, so no more logins are expected
+	resp, err := httpClient.Get(server.URL + "/v1/agent/self")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp.Body.Close()
+
+	if logins.Load() != 2 {
+		t.Errorf("expected 2 logins, got %d", logins.Load())
+	}
+
+	if lastLogin.AuthMethod != "jwt" || lastLogin.BearerToken != bearerToken || lastLogin.Meta["pipeline"] != "42" {
+		t.Errorf("unexpected login parameters: %+v", lastLogin)
+	}
+}
+
+func testJWT(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := json.Marshal(claims)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestValidateJWT(t *testing.T) {
+	now := time.Now()
+
+	testCases := map[string]struct {
+		token       string
+		expectError bool
+	}{
+		"valid": {
+			token: testJWT(t, map[string]interface{}{"exp": now.Add(time.Hour).Unix(), "nbf": now.Add(-time.Hour).Unix()}),
+		},
+		"without expiry": {
+			token: testJWT(t, map[string]interface{}{"sub": "ci"}),
+		},
+		"expired": {
+			token:       testJWT(t, map[string]interface{}{"exp": now.Add(-time.Minute).Unix()}),
+			expectError: true,
+		},
+		"not yet valid": {
+			token:       testJWT(t, map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}),
+			expectError: true,
+		},
+		"empty": {
+			token:       "",
+			expectError: true,
+		},
+		"not a JWT": {
+			token:       "not-a-jwt",
+			expectError: true,
+		},
+		"invalid header": {
+			token:       "e30.e30.signature",
+			expectError: true,
+		},
+		"invalid claims": {
+			token:       base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`)) + ".%%%.signature",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateJWT(testCase.token, now)
+
+			if !testCase.expectError {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			var invalidJWTError *InvalidJWTError
+
+			if !errors.As(err, &invalidJWTError) {
+				t.Fatalf("expected InvalidJWTError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoginToConsulAuthenticationErrors(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_TOKEN", "")
+	t.Setenv("CONSUL_HTTP_TOKEN_FILE", "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/acl/login" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var params api.ACLLoginParams
+
+		_ = json.NewDecoder(r.Body).Decode(&params)
+
+		if params.AuthMethod == "denying" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Permission denied"))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("auth method not found"))
+	}))
+	defer server.Close()
+
+	validJWT := testJWT(t, map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})
+
+	testCases := map[string]struct {
+		clusterModel ConsulClusterModel
+		check        func(err error) bool
+	}{
+		"missing credentials": {
+			clusterModel: ConsulClusterModel{},
+			check: func(err error) bool {
+				var target *MissingCredentialsError
+				return errors.As(err, &target)
+			},
+		},
+		"JWT without auth method": {
+			clusterModel: ConsulClusterModel{
+				ConsulToken: types.StringValue(validJWT),
+			},
+			check: func(err error) bool {
+				var target *MissingCredentialsError
+				return errors.As(err, &target)
+			},
+		},
+		"invalid JWT": {
+			clusterModel: ConsulClusterModel{
+				ConsulToken:   types.StringValue("not-a-jwt"),
+				AclAuthMethod: types.StringValue("jwt"),
+			},
+			check: func(err error) bool {
+				var target *InvalidJWTError
+				return errors.As(err, &target)
+			},
+		},
+		"login failure": {
+			clusterModel: ConsulClusterModel{
+				ConsulToken:   types.StringValue(validJWT),
+				AclAuthMethod: types.StringValue("missing"),
+			},
+			check: func(err error) bool {
+				var target *LoginError
+				return errors.As(err, &target)
+			},
+		},
+		"permission denied": {
+			clusterModel: ConsulClusterModel{
+				ConsulToken:   types.StringValue(validJWT),
+				AclAuthMethod: types.StringValue("denying"),
+			},
+			check: func(err error) bool {
+				var target *PermissionDeniedError
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase.clusterModel.ConsulClusterAddress = types.StringValue(strings.TrimPrefix(server.URL, "http://"))
+
+			var diagnostics diag.Diagnostics
+
+			client, err := loginToConsul(http.DefaultClient, testCase.clusterModel, &diagnostics)
+
+			if client != nil {
+				t.Errorf("expected no client to be returned")
+			}
+
+			if !testCase.check(err) {
+				t.Errorf("unexpected error type %T: %v", err, err)
+			}
+
+			if !diagnostics.HasError() {
+				t.Errorf("expected an error diagnostic")
+			}
+		})
+	}
+}
+
+func TestLoginToConsulWithoutACL(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_TOKEN", "")
+	t.Setenv("CONSUL_HTTP_TOKEN_FILE", "")
+
+	testCases := map[string]struct {
+		clusterModel ConsulClusterModel
+		env          string
+		expectError  bool
+	}{
+		"attribute": {
+			clusterModel: ConsulClusterModel{AclEnabled: types.BoolValue(false)},
+			env:          "true",
+		},
+		"env": {
+			env: "false",
+		},
+		"attribute overrides env": {
+			clusterModel: ConsulClusterModel{AclEnabled: types.BoolValue(true)},
+			env:          "false",
+			expectError:  true,
+		},
+		"enabled by default": {
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("CONSUL_ACL_ENABLED", testCase.env)
+
+			var diagnostics diag.Diagnostics
+
+			client, err := loginToConsul(http.DefaultClient, testCase.clusterModel, &diagnostics)
+
+			if testCase.expectError {
+				var target *MissingCredentialsError
+
+				if !errors.As(err, &target) {
+					t.Fatalf("expected MissingCredentialsError, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil || diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v %v", err, diagnostics)
+			}
+
+			if client == nil {
+				t.Fatalf("expected a client to be returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ConsulClusterModel describes how to reach and authenticate to a consul cluster.
+type ConsulClusterModel struct {
+	ConsulClusterAddress   types.String            `tfsdk:"consul_cluster_address"`
+	ConsulClusterScheme    types.String            `tfsdk:"consul_cluster_scheme"`
+	ConsulToken            types.String            `tfsdk:"consul_token"`
+	ConsulTokenFile        types.String            `tfsdk:"consul_token_file"`
+	ConsulTokenCommand     []types.String          `tfsdk:"consul_token_command"`
+	HttpAuth               types.String            `tfsdk:"http_auth"`
+	Headers                map[string]types.String `tfsdk:"headers"`
+	CaFile                 types.String            `tfsdk:"ca_file"`
+	CaPath                 types.String            `tfsdk:"ca_path"`
+	CertFile               types.String            `tfsdk:"cert_file"`
+	KeyFile                types.String            `tfsdk:"key_file"`
+	TlsServerName          types.String            `tfsdk:"tls_server_name"`
+	InsecureSkipVerify     types.Bool              `tfsdk:"insecure_skip_verify"`
+	AclEnabled             types.Bool              `tfsdk:"acl_enabled"`
+	AclAuthMethod          types.String            `tfsdk:"acl_auth_method"`
+	AclAuthMethodMeta      types.Map               `tfsdk:"acl_auth_method_meta"`
+	AclAuthMethodNamespace types.String            `tfsdk:"acl_auth_method_namespace"`
+	AclAuthMethodPartition types.String            `tfsdk:"acl_auth_method_partition"`
+
+	// Named clusters do not fall back on the CONSUL_* environment variables,
+	// which describe the cluster of the top-level attributes.
+	IgnoreEnvironment bool `tfsdk:"-"`
+}
+
+// ConsulNamedClusterModel describes a cluster block of the provider.
+type ConsulNamedClusterModel struct {
+	Name types.String `tfsdk:"name"`
+	ConsulClusterModel
+}
+
+// getenv returns the value of the environment variable key, unless the
+// cluster ignores the environment.
+func (m ConsulClusterModel) getenv(key string) string {
+	if m.IgnoreEnvironment {
+		return ""
+	}
+
+	return os.Getenv(key)
+}
+
+// consulClusterAttributes returns the attributes configuring a cluster, shared
+// by the top-level cluster and the cluster blocks. envNote is appended to the
+// description of attributes which can also be set by an environment variable.
+func consulClusterAttributes(envNote func(variable string) string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"consul_cluster_address": schema.StringAttribute{
+			MarkdownDescription: "The address of the Consul cluster, such as `127.0.0.1:8500`, `https://gw.example.com/consul` behind a reverse proxy, or `unix:///path/to/consul.sock` to reach an agent on its unix socket." + envNote(api.HTTPAddrEnvName) + " Defaults to `" + defaultConsulAddress + "`.",
+			Optional:            true,
+		},
+		"consul_cluster_scheme": schema.StringAttribute{
+			MarkdownDescription: "The scheme used to connect to the consul cluster. Can be http, https or unix, in which case `consul_cluster_address` is the path of the socket of the agent. Takes precedence over the scheme of the address. Defaults to the scheme of the address, then to https when the `CONSUL_HTTP_SSL` environment variable is true for the top-level cluster, then to http.",
+			Optional:            true,
+		},
+		"consul_token": schema.StringAttribute{
+			MarkdownDescription: "The token used to authenticate to the consul cluster. Can be a JWT formatted token or a UUIDv4 secret ID",
+			Optional:            true,
+		},
+		"consul_token_file": schema.StringAttribute{
+			MarkdownDescription: "Path to a file containing the token used to authenticate to the consul cluster, such as a projected workload identity JWT." + envNote(api.HTTPTokenFileEnvName),
+			Optional:            true,
+		},
+		"consul_token_command": schema.ListAttribute{
+			MarkdownDescription: "Command, and its arguments, printing the token used to authenticate to the consul cluster on its standard output within 30 seconds",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"http_auth": schema.StringAttribute{
+			MarkdownDescription: "HTTP basic auth credentials sent to the consul cluster, formatted as `username[:password]`." + envNote(api.HTTPAuthEnvName),
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"headers": schema.MapAttribute{
+			MarkdownDescription: "Additional HTTP headers sent with each request to the consul cluster, such as a routing header expected by a proxy in front of it.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"ca_file": schema.StringAttribute{
+			MarkdownDescription: "Path to a PEM encoded CA certificate used to verify the certificate of the consul cluster." + envNote(api.HTTPCAFile),
+			Optional:            true,
+		},
+		"ca_path": schema.StringAttribute{
+			MarkdownDescription: "Path to a directory of PEM encoded CA certificates used to verify the certificate of the consul cluster." + envNote(api.HTTPCAPath),
+			Optional:            true,
+		},
+		"cert_file": schema.StringAttribute{
+			MarkdownDescription: "Path to a PEM encoded client certificate, for clusters verifying incoming connections." + envNote(api.HTTPClientCert),
+			Optional:            true,
+		},
+		"key_file": schema.StringAttribute{
+			MarkdownDescription: "Path to the PEM encoded private key of `cert_file`." + envNote(api.HTTPClientKey),
+			Optional:            true,
+		},
+		"tls_server_name": schema.StringAttribute{
+			MarkdownDescription: "Server name expected in the certificate of the consul cluster, when it differs from the host of the address." + envNote(api.HTTPTLSServerName),
+			Optional:            true,
+		},
+		"insecure_skip_verify": schema.BoolAttribute{
+			MarkdownDescription: "Whether to skip the verification of the certificate of the consul cluster. Only meant for tests. The verification of the top-level cluster can also be disabled by setting the `" + api.HTTPSSLVerifyEnvName + "` environment variable to false.",
+			Optional:            true,
+		},
+		"acl_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether ACLs are enabled on the consul cluster. When false, no token is needed and requests are sent anonymously, which suits dev agents." + envNote("CONSUL_ACL_ENABLED") + " Defaults to true.",
+			Optional:            true,
+		},
+		"acl_auth_method": schema.StringAttribute{
+			MarkdownDescription: "Auth method used when the token is JWT encoded. Not needed if the token is a UUIDv4 secret ID.",
+			Optional:            true,
+		},
+		"acl_auth_method_meta": schema.MapAttribute{
+			MarkdownDescription: "Metadata attached to the token created when logging in to `acl_auth_method`, such as the CI pipeline or repository.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"acl_auth_method_namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace of `acl_auth_method`. Defaults to the namespace of the agent.",
+			Optional:            true,
+		},
+		"acl_auth_method_partition": schema.StringAttribute{
+			MarkdownDescription: "Partition of `acl_auth_method`. Defaults to the partition of the agent.",
+			Optional:            true,
+		},
+	}
+}
+
+// resolveTLSConfig returns the TLS settings of the cluster, or nil when it uses the defaults.
+func resolveTLSConfig(clusterModel ConsulClusterModel) (*api.TLSConfig, error) {
+	tlsConfig := api.TLSConfig{
+		CAFile:   clusterModel.CaFile.ValueString(),
+		CAPath:   clusterModel.CaPath.ValueString(),
+		CertFile: clusterModel.CertFile.ValueString(),
+		KeyFile:  clusterModel.KeyFile.ValueString(),
+		Address:  clusterModel.TlsServerName.ValueString(),
+	}
+
+	fallbacks := []struct {
+		target   *string
+		variable string
+	}{
+		{&tlsConfig.CAFile, api.HTTPCAFile},
+		{&tlsConfig.CAPath, api.HTTPCAPath},
+		{&tlsConfig.CertFile, api.HTTPClientCert},
+		{&tlsConfig.KeyFile, api.HTTPClientKey},
+		{&tlsConfig.Address, api.HTTPTLSServerName},
+	}
+
+	for _, fallback := range fallbacks {
+		if *fallback.target == "" {
+			*fallback.target = clusterModel.getenv(fallback.variable)
+		}
+	}
+
+	if !clusterModel.InsecureSkipVerify.IsNull() {
+		tlsConfig.InsecureSkipVerify = clusterModel.InsecureSkipVerify.ValueBool()
+	} else if verify := clusterModel.getenv(api.HTTPSSLVerifyEnvName); verify != "" {
+		enabled, err := strconv.ParseBool(verify)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", api.HTTPSSLVerifyEnvName, verify, err)
+		}
+
+		tlsConfig.InsecureSkipVerify = !enabled
+	}
+
+	if tlsConfig.CAFile == "" && tlsConfig.CAPath == "" && tlsConfig.CertFile == "" && tlsConfig.KeyFile == "" && tlsConfig.Address == "" && !tlsConfig.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return nil, fmt.Errorf("cert_file and key_file must be set together")
+	}
+
+	return &tlsConfig, nil
+}
+
+// withTLSConfig returns a copy of httpClient verifying and authenticating
+// its connections according to tlsConfig.
+func withTLSConfig(httpClient *http.Client, tlsConfig *api.TLSConfig) (*http.Client, error) {
+	tlsClientConfig, err := api.SetupTLSConfig(tlsConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withBaseTransport(httpClient, func(transport *http.Transport) {
+		transport.TLSClientConfig = tlsClientConfig
+	}), nil
+}
+
+// UtilsProviderData is handed to resources and data sources when they are
+// configured, and creates the client of the cluster each of them uses.
+type UtilsProviderData struct {
+	RetryPolicy RetryPolicy
+
+	httpClient     *http.Client
+	defaultCluster ConsulClusterModel
+	clusters       map[string]ConsulClusterModel
+
+	// Clients are created once per cluster, so its credentials are only
+	// resolved, and the auth method logged in to, once per run.
+	lock    sync.Mutex
+	clients map[string]*api.Client
+}
+
+func newUtilsProviderData(httpClient *http.Client, retryPolicy RetryPolicy, defaultCluster ConsulClusterModel, clusters map[string]ConsulClusterModel) *UtilsProviderData {
+	return &UtilsProviderData{
+		RetryPolicy:    retryPolicy,
+		httpClient:     httpClient,
+		defaultCluster: defaultCluster,
+		clusters:       clusters,
+		clients:        make(map[string]*api.Client),
+	}
+}
+
+// Client returns the client of the cluster block named name, or of the
+// cluster of the top-level attributes when name is empty.
+func (d *UtilsProviderData) Client(name string, diagnostics *diag.Diagnostics) (*api.Client, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if client, ok := d.clients[name]; ok {
+		return client, nil
+	}
+
+	clusterModel := d.defaultCluster
+
+	if name != "" {
+		var ok bool
+
+		clusterModel, ok = d.clusters[name]
+
+		if !ok {
+			names := make([]string, 0, len(d.clusters))
+
+			for clusterName := range d.clusters {
+				names = append(names, fmt.Sprintf("%q", clusterName))
+			}
+
+			sort.Strings(names)
+
+			diagnostics.AddError(
+				"Unknown Consul Cluster",
+				fmt.Sprintf("No cluster block of the provider is named %q. Configured clusters: [%s].", name, strings.Join(names, ", ")),
+			)
+
+			return nil, fmt.Errorf("unknown cluster %q", name)
+		}
+	}
+
+	client, err := loginToConsul(d.httpClient, clusterModel, diagnostics)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.clients[name] = client
+
+	return client, nil
+}
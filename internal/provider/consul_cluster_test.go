@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUtilsProviderDataClient(t *testing.T) {
+	var defaultRequests, namedRequests atomic.Int32
+
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultRequests.Add(1)
+		_, _ = w.Write([]byte(`"10.0.0.1:8300"`))
+	}))
+	defer defaultServer.Close()
+
+	namedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namedRequests.Add(1)
+
+		if r.Header.Get("X-Consul-Token") != "00000000-0000-0000-0000-000000000002" {
+			t.Errorf("expected the token of the named cluster, got %q", r.Header.Get("X-Consul-Token"))
+		}
+
+		_, _ = w.Write([]byte(`"10.0.1.1:8300"`))
+	}))
+	defer namedServer.Close()
+
+	// The environment only configures the top-level cluster
+	t.Setenv("CONSUL_HTTP_ADDR", defaultServer.URL)
+	t.Setenv("CONSUL_HTTP_TOKEN", "00000000-0000-0000-0000-000000000001")
+	t.Setenv("CONSUL_ACL_ENABLED", "")
+
+	providerData := newUtilsProviderData(http.DefaultClient, testRetryPolicy, ConsulClusterModel{}, map[string]ConsulClusterModel{
+		"secondary": {
+			ConsulClusterAddress: types.StringValue(strings.TrimPrefix(namedServer.URL, "http://")),
+			ConsulToken:          types.StringValue("00000000-0000-0000-0000-000000000002"),
+			IgnoreEnvironment:    true,
+		},
+	})
+
+	var diagnostics diag.Diagnostics
+
+	for _, name := range []string{"", "secondary"} {
+		client, err := providerData.Client(name, &diagnostics)
+
+		if err != nil {
+			t.Fatalf("cluster %q: %s", name, err)
+		}
+
+		if _, err := client.Status().Leader(); err != nil {
+			t.Fatalf("cluster %q: %s", name, err)
+		}
+
+		again, _ := providerData.Client(name, &diagnostics)
+
+		if again != client {
+			t.Errorf("cluster %q: expected the client to be reused", name)
+		}
+	}
+
+	if defaultRequests.Load() != 1 || namedRequests.Load() != 1 {
+		t.Errorf("expected one request per cluster, got %d and %d", defaultRequests.Load(), namedRequests.Load())
+	}
+
+	if _, err := providerData.Client("unknown", &diagnostics); err == nil || !diagnostics.HasError() {
+		t.Errorf("expected an error diagnostic for an unknown cluster")
+	}
+}
+
+func TestNamedClusterIgnoresEnvironment(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_TOKEN", "00000000-0000-0000-0000-000000000001")
+	t.Setenv("CONSUL_CACERT", "/etc/consul/ca.pem")
+
+	if _, err := resolveConsulToken(ConsulClusterModel{IgnoreEnvironment: true}); err == nil {
+		t.Error("expected a named cluster not to use CONSUL_HTTP_TOKEN")
+	}
+
+	if tlsConfig, err := resolveTLSConfig(ConsulClusterModel{IgnoreEnvironment: true}); err != nil || tlsConfig != nil {
+		t.Errorf("expected a named cluster not to use CONSUL_CACERT, got %+v, %v", tlsConfig, err)
+	}
+
+	tlsConfig, err := resolveTLSConfig(ConsulClusterModel{})
+
+	if err != nil || tlsConfig == nil || tlsConfig.CAFile != "/etc/consul/ca.pem" {
+		t.Errorf("expected the top-level cluster to use CONSUL_CACERT, got %+v, %v", tlsConfig, err)
+	}
+}
+
+func TestResolveTLSConfig(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_SSL_VERIFY", "false")
+
+	tlsConfig, err := resolveTLSConfig(ConsulClusterModel{InsecureSkipVerify: types.BoolNull()})
+
+	if err != nil || tlsConfig == nil || !tlsConfig.InsecureSkipVerify {
+		t.Errorf("expected CONSUL_HTTP_SSL_VERIFY=false to skip the verification, got %+v, %v", tlsConfig, err)
+	}
+
+	tlsConfig, err = resolveTLSConfig(ConsulClusterModel{InsecureSkipVerify: types.BoolValue(false), TlsServerName: types.StringValue("consul.example.com")})
+
+	if err != nil || tlsConfig == nil || tlsConfig.InsecureSkipVerify || tlsConfig.Address != "consul.example.com" {
+		t.Errorf("expected the attributes to take precedence, got %+v, %v", tlsConfig, err)
+	}
+
+	if _, err := resolveTLSConfig(ConsulClusterModel{CertFile: types.StringValue("client.pem")}); err == nil {
+		t.Error("expected an error when cert_file is set without key_file")
+	}
+}
+
+func TestLoginToConsulOverTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`"10.0.0.1:8300"`))
+	}))
+	defer server.Close()
+
+	clusterModel := ConsulClusterModel{
+		ConsulClusterAddress: types.StringValue(server.URL),
+		AclEnabled:           types.BoolValue(false),
+		IgnoreEnvironment:    true,
+	}
+
+	var diagnostics diag.Diagnostics
+
+	client, err := loginToConsul(newRetryingHTTPClient(context.Background(), RetryPolicy{}), clusterModel, &diagnostics)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Status().Leader(); err == nil {
+		t.Error("expected the self-signed certificate to be rejected")
+	}
+
+	clusterModel.InsecureSkipVerify = types.BoolValue(true)
+
+	client, err = loginToConsul(newRetryingHTTPClient(context.Background(), RetryPolicy{}), clusterModel, &diagnostics)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Status().Leader(); err != nil {
+		t.Errorf("expected the certificate verification to be skipped, got %s", err)
+	}
+}
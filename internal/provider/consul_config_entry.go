@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	api "github.com/hashicorp/consul/api"
+)
+
+var configEntryMutexes map[string]*sync.Mutex
+var configEntryMutexesLock sync.Mutex
+
+// getMutexForConfigEntry returns the mutex serializing the modifications of
+// the fragments of a config entry made by this provider. The client, shared
+// by the resources of a cluster, tells apart the entries of each cluster.
+func getMutexForConfigEntry(client *api.Client, kind, name string) *sync.Mutex {
+	configEntryMutexesLock.Lock()
+	defer configEntryMutexesLock.Unlock()
+
+	if configEntryMutexes == nil {
+		configEntryMutexes = make(map[string]*sync.Mutex)
+	}
+
+	id := fmt.Sprintf("%p/%s/%s", client, kind, name)
+
+	if _, ok := configEntryMutexes[id]; !ok {
+		configEntryMutexes[id] = &sync.Mutex{}
+	}
+
+	return configEntryMutexes[id]
+}
+
+// readConfigEntry returns the config entry, or nil when it does not exist.
+func readConfigEntry(client *api.Client, kind, name string) (api.ConfigEntry, error) {
+	configEntry, _, err := client.ConfigEntries().Get(kind, name, nil)
+
+	if err != nil {
+		var statusError api.StatusError
+
+		if errors.As(err, &statusError) && statusError.Code == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read config entry %s/%s: %w", kind, name, err)
+	}
+
+	return configEntry, nil
+}
+
+// readTypedConfigEntry returns the config entry, or false when it does not exist.
+func readTypedConfigEntry[T api.ConfigEntry](client *api.Client, kind, name string) (T, bool, error) {
+	var typed T
+
+	configEntry, err := readConfigEntry(client, kind, name)
+
+	if err != nil || configEntry == nil {
+		return typed, false, err
+	}
+
+	typed, ok := configEntry.(T)
+
+	if !ok {
+		return typed, false, fmt.Errorf("config entry %s/%s has unexpected type %T", kind, name, configEntry)
+	}
+
+	return typed, true, nil
+}
+
+// updateConfigEntry applies update to a config entry with a CAS write,
+// reading it again when another writer modified it in between. update is
+// given nil when the entry does not exist, and returns nil to delete it.
+func updateConfigEntry(ctx context.Context, client *api.Client, retryPolicy RetryPolicy, kind, name string, update func(configEntry api.ConfigEntry) (api.ConfigEntry, error)) error {
+	configEntryMutex := getMutexForConfigEntry(client, kind, name)
+
+	configEntryMutex.Lock()
+	defer configEntryMutex.Unlock()
+
+	return retryOnCASConflict(ctx, retryPolicy, kind+"/"+name, func() (bool, error) {
+		configEntry, err := readConfigEntry(client, kind, name)
+
+		if err != nil {
+			return false, err
+		}
+
+		var modifyIndex uint64
+
+		if configEntry != nil {
+			modifyIndex = configEntry.GetModifyIndex()
+		}
+
+		updated, err := update(configEntry)
+
+		if err != nil {
+			return false, err
+		}
+
+		if updated == nil {
+			// The entry never existed, so there is nothing to delete
+			if modifyIndex == 0 {
+				return true, nil
+			}
+
+			written, _, err := client.ConfigEntries().DeleteCAS(kind, name, modifyIndex, nil)
+
+			return written, err
+		}
+
+		written, _, err := client.ConfigEntries().CAS(updated, modifyIndex, nil)
+
+		return written, err
+	})
+}
+
+// updateConfigEntryFragment applies update to one fragment of a config entry
+// of type T, creating the entry with newEntry when it does not exist, and
+// deleting it once isEmpty tells that no fragment is left.
+func updateConfigEntryFragment[T api.ConfigEntry](ctx context.Context, client *api.Client, retryPolicy RetryPolicy, kind, name string, newEntry func() T, isEmpty func(configEntry T) bool, update func(configEntry T) error) error {
+	return updateConfigEntry(ctx, client, retryPolicy, kind, name, func(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+		typed := newEntry()
+
+		if configEntry != nil {
+			var ok bool
+
+			typed, ok = configEntry.(T)
+
+			if !ok {
+				return nil, fmt.Errorf("config entry %s/%s has unexpected type %T", kind, name, configEntry)
+			}
+		}
+
+		if err := update(typed); err != nil {
+			return nil, err
+		}
+
+		if isEmpty(typed) {
+			return nil, nil
+		}
+
+		return typed, nil
+	})
+}
@@ -0,0 +1,504 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	mergePatchType = "merge"
+	jsonPatchType  = "json"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulConfigEntryPatchResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulConfigEntryPatchResource{}
+
+func NewConsulConfigEntryPatchResource() resource.Resource {
+	return &ConsulConfigEntryPatchResource{}
+}
+
+// ConsulConfigEntryPatchResource defines the resource implementation.
+type ConsulConfigEntryPatchResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulConfigEntryPatchResourceModel describes the resource data model.
+type ConsulConfigEntryPatchResourceModel struct {
+	Cluster      types.String `tfsdk:"cluster"`
+	Kind         types.String `tfsdk:"kind"`
+	Name         types.String `tfsdk:"name"`
+	PatchType    types.String `tfsdk:"patch_type"`
+	Patch        types.String `tfsdk:"patch"`
+	InversePatch types.String `tfsdk:"inverse_patch"`
+	Id           types.String `tfsdk:"id"`
+}
+
+func (r *ConsulConfigEntryPatchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_config_entry_patch"
+}
+
+func (r *ConsulConfigEntryPatchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Applies a JSON merge patch (RFC 7386) or a JSON patch (RFC 6902) to a config entry of any kind, so that several teams can contribute to the same entry. " +
+			"On destroy, the inverse of the patch is applied, removing only the values set by the patch which were not modified by others since.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "The kind of the config entry, such as `service-defaults`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the config entry, created when it does not exist",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"patch_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the patch, either `merge` for a JSON merge patch or `json` for a JSON patch. Defaults to `merge`. " +
+					"As JSON patches, such as the ones appending to arrays, may not be applied twice, the changes made by others to the values of a `json` patch are not detected, while a `merge` patch is applied again once they are.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(mergePatchType),
+			},
+			"patch": schema.StringAttribute{
+				MarkdownDescription: "The JSON patch document, whose paths use the field names of the JSON representation of the config entry, such as `/Protocol`",
+				Required:            true,
+			},
+			"inverse_patch": schema.StringAttribute{
+				MarkdownDescription: "The JSON patch undoing the patch, applied on destroy",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Config entry patch identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulConfigEntryPatchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ConsulConfigEntryPatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConsulConfigEntryPatchResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.PatchType.IsUnknown() || data.Patch.IsUnknown() {
+		return
+	}
+
+	if patchType := data.PatchType.ValueString(); patchType != "" && patchType != mergePatchType && patchType != jsonPatchType {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("patch_type"),
+			"Invalid Patch Type",
+			fmt.Sprintf("The patch type must be either %q or %q, got %q.", mergePatchType, jsonPatchType, patchType),
+		)
+
+		return
+	}
+
+	if _, err := data.operations(map[string]any{}); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("patch"), "Invalid Patch", err.Error())
+	}
+}
+
+// operations returns the JSON patch operations applying the patch to doc.
+func (data ConsulConfigEntryPatchResourceModel) operations(doc any) ([]jsonPatchOperation, error) {
+	if data.PatchType.ValueString() == jsonPatchType {
+		return decodeJSONPatch([]byte(data.Patch.ValueString()))
+	}
+
+	patch, err := decodeJSON([]byte(data.Patch.ValueString()))
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON merge patch: %w", err)
+	}
+
+	if _, ok := patch.(map[string]any); !ok {
+		return nil, fmt.Errorf("the JSON merge patch of a config entry must be an object")
+	}
+
+	return mergePatchOperations(doc, patch), nil
+}
+
+// inverseOperations returns the operations undoing the patch recorded in the state.
+func (data ConsulConfigEntryPatchResourceModel) inverseOperations() ([]jsonPatchOperation, error) {
+	if data.InversePatch.IsNull() || data.InversePatch.IsUnknown() {
+		return nil, nil
+	}
+
+	return decodeJSONPatch([]byte(data.InversePatch.ValueString()))
+}
+
+// document returns the JSON document of configEntry, or of an empty entry when it does not exist.
+func (data ConsulConfigEntryPatchResourceModel) document(configEntry api.ConfigEntry) (any, error) {
+	if configEntry == nil {
+		return map[string]any{
+			"Kind": data.Kind.ValueString(),
+			"Name": data.Name.ValueString(),
+		}, nil
+	}
+
+	encoded, err := json.Marshal(configEntry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON(encoded)
+}
+
+// configEntry returns the config entry of doc, or nil when it holds nothing but its kind and name.
+func (data ConsulConfigEntryPatchResourceModel) configEntry(doc any) (api.ConfigEntry, error) {
+	object, ok := doc.(map[string]any)
+
+	if !ok || object["Kind"] != data.Kind.ValueString() || object["Name"] != data.Name.ValueString() {
+		return nil, fmt.Errorf("the patch must not change the Kind nor the Name of the config entry")
+	}
+
+	configEntry, err := api.DecodeConfigEntryFromJSON(encodeJSONValue(doc))
+
+	if err != nil {
+		return nil, fmt.Errorf("the patched config entry is invalid: %w", err)
+	}
+
+	empty, err := data.configEntryIsEmpty(configEntry)
+
+	if err != nil || empty {
+		return nil, err
+	}
+
+	return configEntry, nil
+}
+
+func (data ConsulConfigEntryPatchResourceModel) configEntryIsEmpty(configEntry api.ConfigEntry) (bool, error) {
+	emptyEntry, err := api.DecodeConfigEntryFromJSON(encodeJSONValue(map[string]any{
+		"Kind": data.Kind.ValueString(),
+		"Name": data.Name.ValueString(),
+	}))
+
+	if err != nil {
+		return false, err
+	}
+
+	actual, err := data.document(configEntry)
+
+	if err != nil {
+		return false, err
+	}
+
+	expected, err := data.document(emptyEntry)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, doc := range []any{actual, expected} {
+		delete(doc.(map[string]any), "CreateIndex")
+		delete(doc.(map[string]any), "ModifyIndex")
+	}
+
+	return jsonEqual(actual, expected), nil
+}
+
+// apply returns the update of the config entry undoing the previous patch,
+// then applying the patch and recording its inverse in data.
+func (data *ConsulConfigEntryPatchResourceModel) apply(previous []jsonPatchOperation) func(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	return func(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+		doc, err := data.document(configEntry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		doc = undoJSONPatch(doc, previous)
+
+		operations, err := data.operations(doc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		patched, inverse, err := applyJSONPatch(doc, operations)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to apply the patch: %w", err)
+		}
+
+		encoded, err := json.Marshal(append([]jsonPatchOperation{}, inverse...))
+
+		if err != nil {
+			return nil, err
+		}
+
+		data.InversePatch = types.StringValue(string(encoded))
+
+		return data.configEntry(patched)
+	}
+}
+
+// undo returns the update of the config entry undoing the patch.
+func (data ConsulConfigEntryPatchResourceModel) undo(inverse []jsonPatchOperation) func(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	return func(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+		// Nothing to undo in a deleted entry
+		if configEntry == nil {
+			return nil, nil
+		}
+
+		doc, err := data.document(configEntry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return data.configEntry(undoJSONPatch(doc, inverse))
+	}
+}
+
+// isApplied tells whether applying the merge patch again leaves configEntry unchanged.
+func (data ConsulConfigEntryPatchResourceModel) isApplied(configEntry api.ConfigEntry) (bool, error) {
+	// JSON patches, such as the ones appending to arrays, may not be idempotent
+	if data.PatchType.ValueString() != mergePatchType {
+		return true, nil
+	}
+
+	doc, err := data.document(configEntry)
+
+	if err != nil {
+		return false, err
+	}
+
+	operations, err := data.operations(doc)
+
+	if err != nil {
+		return false, err
+	}
+
+	patched, _, err := applyJSONPatch(doc, operations)
+
+	if err != nil {
+		return false, err
+	}
+
+	// The patched entry is normalized like the one read from consul
+	patchedEntry, err := api.DecodeConfigEntryFromJSON(encodeJSONValue(patched))
+
+	if err != nil {
+		return false, err
+	}
+
+	patched, err = data.document(patchedEntry)
+
+	if err != nil {
+		return false, err
+	}
+
+	return jsonEqual(doc, patched), nil
+}
+
+func (data ConsulConfigEntryPatchResourceModel) id() string {
+	return fmt.Sprintf("%s_%s", data.Kind.ValueString(), data.Name.ValueString())
+}
+
+func (r *ConsulConfigEntryPatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulConfigEntryPatchResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, data.Kind.ValueString(), data.Name.ValueString(), data.apply(nil))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write config entry, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "config entry patch")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulConfigEntryPatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulConfigEntryPatchResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, err := readConfigEntry(client, data.Kind.ValueString(), data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read config entry, got error: %s", err))
+		return
+	}
+
+	if configEntry == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	applied, err := data.isApplied(configEntry)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read config entry, got error: %s", err))
+		return
+	}
+
+	if !applied {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulConfigEntryPatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ConsulConfigEntryPatchResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	previous, err := state.inverseOperations()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Inverse Patch", err.Error())
+		return
+	}
+
+	// The previous patch is undone in the same write as the new one is applied
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, data.Kind.ValueString(), data.Name.ValueString(), data.apply(previous))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write config entry, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "config entry patch")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulConfigEntryPatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulConfigEntryPatchResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	inverse, err := data.inverseOperations()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Inverse Patch", err.Error())
+		return
+	}
+
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, data.Kind.ValueString(), data.Name.ValueString(), data.undo(inverse))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write config entry, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulConfigEntryPatchResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_config_entry_patch" "test" {
+	kind  = "service-defaults"
+	name  = "invalid-service"
+	patch = jsonencode({ Protocol = "http" })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_config_entry_patch.test", "patch_type", "merge"),
+					resource.TestCheckResourceAttr("utils_consul_config_entry_patch.test", "id", "service-defaults_invalid-service"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_config_entry_patch" "test" {
+	kind       = "service-defaults"
+	name       = "invalid-service"
+	patch_type = "json"
+	patch      = jsonencode([{ op = "add", path = "/MaxInboundConnections", value = 10 }])
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_config_entry_patch.test", "patch_type", "json"),
+				),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestConfigEntryPatches(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+	ctx := context.Background()
+
+	protocol := ConsulConfigEntryPatchResourceModel{
+		Kind:      types.StringValue(api.ServiceDefaults),
+		Name:      types.StringValue("web"),
+		PatchType: types.StringValue(mergePatchType),
+		Patch:     types.StringValue(`{"Protocol": "http"}`),
+	}
+
+	connections := ConsulConfigEntryPatchResourceModel{
+		Kind:      types.StringValue(api.ServiceDefaults),
+		Name:      types.StringValue("web"),
+		PatchType: types.StringValue(jsonPatchType),
+		Patch:     types.StringValue(`[{"op": "add", "path": "/MaxInboundConnections", "value": 10}]`),
+	}
+
+	for _, data := range []*ConsulConfigEntryPatchResourceModel{&protocol, &connections} {
+		if err := updateConfigEntry(ctx, client, testRetryPolicy, api.ServiceDefaults, "web", data.apply(nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configEntry, _, err := readTypedConfigEntry[*api.ServiceConfigEntry](client, api.ServiceDefaults, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if configEntry.Protocol != "http" || configEntry.MaxInboundConnections != 10 {
+		t.Fatalf("expected both patches to be applied, got %+v", configEntry)
+	}
+
+	if applied, err := protocol.isApplied(configEntry); err != nil || !applied {
+		t.Errorf("expected the merge patch to be detected as applied, got %v, %v", applied, err)
+	}
+
+	// Updating the patch undoes the previous one in the same write
+	previous, err := protocol.inverseOperations()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protocol.Patch = types.StringValue(`{"Protocol": "grpc"}`)
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.ServiceDefaults, "web", protocol.apply(previous)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range []ConsulConfigEntryPatchResourceModel{protocol, connections} {
+		inverse, err := data.inverseOperations()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := updateConfigEntry(ctx, client, testRetryPolicy, api.ServiceDefaults, "web", data.undo(inverse)); err != nil {
+			t.Fatal(err)
+		}
+
+		configEntry, _, err = readTypedConfigEntry[*api.ServiceConfigEntry](client, api.ServiceDefaults, "web")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if configEntry != nil && configEntry.Protocol != "" {
+			t.Errorf("expected the protocol to be removed, got %+v", configEntry)
+		}
+	}
+
+	if entry := store.entry(api.ServiceDefaults, "web"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last patch, got %+v", entry)
+	}
+
+	renaming := ConsulConfigEntryPatchResourceModel{
+		Kind:      types.StringValue(api.ServiceDefaults),
+		Name:      types.StringValue("web"),
+		PatchType: types.StringValue(mergePatchType),
+		Patch:     types.StringValue(`{"Name": "api"}`),
+	}
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.ServiceDefaults, "web", renaming.apply(nil)); err == nil {
+		t.Error("expected an error when the patch renames the entry")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testConfigEntryStore is an in-memory implementation of the config entry
+// endpoints of consul, checking the CAS indexes of the writes.
+type testConfigEntryStore struct {
+	lock        sync.Mutex
+	entries     map[string]map[string]any
+	modifyIndex uint64
+	writes      int
+}
+
+// newTestConfigEntryClient returns a client of a new config entry store.
+func newTestConfigEntryClient(t *testing.T) (*api.Client, *testConfigEntryStore) {
+	store := &testConfigEntryStore{entries: make(map[string]map[string]any)}
+
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, store
+}
+
+// testAccWriteConfigEntries writes the config entries the resources of the
+// tests depend on to the consul cluster of the acceptance tests, and deletes
+// them once the test is done, in the reverse order.
+func testAccWriteConfigEntries(t *testing.T, configEntries ...api.ConfigEntry) {
+	client, err := api.NewClient(api.DefaultConfig())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, configEntry := range configEntries {
+		if _, _, err := client.ConfigEntries().Set(configEntry, nil); err != nil {
+			t.Fatalf("unable to write %s %q: %s", configEntry.GetKind(), configEntry.GetName(), err)
+		}
+
+		t.Cleanup(func() {
+			if _, err := client.ConfigEntries().Delete(configEntry.GetKind(), configEntry.GetName(), nil); err != nil {
+				t.Errorf("unable to delete %s %q: %s", configEntry.GetKind(), configEntry.GetName(), err)
+			}
+		})
+	}
+}
+
+func (s *testConfigEntryStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/config/")
+	cas, hasCAS := r.URL.Query().Get("cas"), r.URL.Query().Has("cas")
+
+	currentIndex := func(id string) string {
+		if entry, ok := s.entries[id]; ok {
+			return strconv.FormatUint(uint64(entry["ModifyIndex"].(float64)), 10)
+		}
+
+		return "0"
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		entry, ok := s.entries[id]
+
+		if !ok {
+			http.Error(w, "Config entry not found for "+id, http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(entry)
+	case http.MethodPut:
+		var entry map[string]any
+
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		id = fmt.Sprintf("%s/%s", entry["Kind"], entry["Name"])
+
+		if hasCAS && cas != currentIndex(id) {
+			_, _ = fmt.Fprint(w, false)
+			return
+		}
+
+		s.writes++
+		s.modifyIndex++
+		entry["ModifyIndex"] = float64(s.modifyIndex)
+		s.entries[id] = entry
+
+		_, _ = fmt.Fprint(w, true)
+	case http.MethodDelete:
+		if hasCAS && cas != currentIndex(id) {
+			_, _ = fmt.Fprint(w, `{"Deleted": false}`)
+			return
+		}
+
+		delete(s.entries, id)
+
+		_, _ = fmt.Fprint(w, `{"Deleted": true}`)
+	}
+}
+
+// entry returns the stored config entry, or nil when it does not exist.
+func (s *testConfigEntryStore) entry(kind, name string) map[string]any {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.entries[kind+"/"+name]
+}
+
+func TestReadConfigEntryNotFound(t *testing.T) {
+	client, _ := newTestConfigEntryClient(t)
+
+	configEntry, err := readConfigEntry(client, api.ServiceDefaults, "web")
+
+	if err != nil || configEntry != nil {
+		t.Errorf("expected no entry and no error, got %+v, %v", configEntry, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err = api.NewClient(&api.Config{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readConfigEntry(client, api.ServiceDefaults, "web"); err == nil {
+		t.Error("expected the errors other than not found to be returned")
+	}
+}
+
+func TestUpdateServiceDefaultsFragments(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	update := func(update func(configEntry *api.ServiceConfigEntry)) {
+		t.Helper()
+
+		err := updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.ServiceDefaults, "web", newServiceDefaults("web"), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+			update(configEntry)
+			return nil
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	protocol := ConsulServiceDefaultsFieldResourceModel{Protocol: types.StringValue("http")}
+	addOverride := func(configEntry *api.ServiceConfigEntry) {
+		setUpstreamOverride(configEntry, &api.UpstreamConfig{Name: "db", Protocol: "tcp"})
+	}
+	removeOverride := func(configEntry *api.ServiceConfigEntry) {
+		removeUpstreamOverride(configEntry, "db", "")
+	}
+
+	update(protocol.setField)
+	update(addOverride)
+
+	entry := store.entry(api.ServiceDefaults, "web")
+
+	if entry == nil || entry["Protocol"] != "http" || entry["UpstreamConfig"] == nil {
+		t.Fatalf("expected both fragments to be written, got %+v", entry)
+	}
+
+	update(protocol.clearField)
+
+	entry = store.entry(api.ServiceDefaults, "web")
+
+	if entry == nil || entry["Protocol"] != nil {
+		t.Fatalf("expected only the protocol to be removed, got %+v", entry)
+	}
+
+	update(removeOverride)
+
+	if entry := store.entry(api.ServiceDefaults, "web"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last fragment, got %+v", entry)
+	}
+
+	// Removing a fragment of an entry which does not exist does not create it
+	update(removeOverride)
+
+	if entry := store.entry(api.ServiceDefaults, "web"); entry != nil {
+		t.Errorf("expected no entry to be created, got %+v", entry)
+	}
+}
+
+func TestGetMutexForConfigEntry(t *testing.T) {
+	client, _ := newTestConfigEntryClient(t)
+	otherClient, _ := newTestConfigEntryClient(t)
+
+	if getMutexForConfigEntry(client, api.ServiceDefaults, "web") != getMutexForConfigEntry(client, api.ServiceDefaults, "web") {
+		t.Error("expected the same entry of a cluster to share its mutex")
+	}
+
+	if getMutexForConfigEntry(client, api.ServiceDefaults, "web") == getMutexForConfigEntry(otherClient, api.ServiceDefaults, "web") {
+		t.Error("expected the entries of different clusters not to share their mutex")
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	api "github.com/hashicorp/consul/api"
+)
+
+const defaultConsulAddress = "127.0.0.1:8500"
+
+// Host sent to agents listening on a unix socket, which ignore it
+const unixSocketHost = "consul"
+
+// consulEndpoint is where the consul HTTP API is reached.
+type consulEndpoint struct {
+	// Empty when the address does not specify it
+	Scheme string
+	// host[:port], or the path of the socket for the unix scheme
+	Host string
+	// Path of a reverse proxy in front of the API, without trailing slash
+	PathPrefix string
+}
+
+// parseConsulAddress parses addresses such as 127.0.0.1:8500, [::1]:8500,
+// https://gw.example/consul/ or unix:///var/run/consul.sock.
+func parseConsulAddress(rawAddress string) (consulEndpoint, error) {
+	rawAddress = strings.TrimSpace(rawAddress)
+
+	if socketPath, ok := strings.CutPrefix(rawAddress, "unix://"); ok {
+		if socketPath == "" {
+			return consulEndpoint{}, fmt.Errorf("invalid consul address %q: missing socket path", rawAddress)
+		}
+
+		return consulEndpoint{Scheme: "unix", Host: socketPath}, nil
+	}
+
+	scheme, rest, hasScheme := strings.Cut(rawAddress, "://")
+
+	if !hasScheme {
+		scheme, rest = "", rawAddress
+	}
+
+	// A bare IPv6 host, such as ::1, is only valid within brackets
+	if ip := net.ParseIP(rest); ip != nil && strings.Contains(rest, ":") {
+		rest = "[" + rest + "]"
+	}
+
+	// The scheme is only needed to parse the address, it is not returned when absent
+	parsed, err := url.Parse("http://" + rest)
+
+	if err != nil {
+		return consulEndpoint{}, fmt.Errorf("invalid consul address %q: %w", rawAddress, err)
+	}
+
+	switch scheme {
+	case "", "http", "https":
+	default:
+		return consulEndpoint{}, fmt.Errorf("invalid consul address %q: unsupported scheme %q, expected http, https or unix", rawAddress, scheme)
+	}
+
+	if parsed.Host == "" || parsed.Hostname() == "" {
+		return consulEndpoint{}, fmt.Errorf("invalid consul address %q: missing host", rawAddress)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return consulEndpoint{}, fmt.Errorf("invalid consul address %q: only a scheme, a host, a port and a path are allowed", rawAddress)
+	}
+
+	return consulEndpoint{
+		Scheme:     scheme,
+		Host:       parsed.Host,
+		PathPrefix: strings.TrimRight(parsed.Path, "/"),
+	}, nil
+}
+
+// resolveConsulAddress returns the address of the consul cluster:
+//   - consul_cluster_address, then the CONSUL_HTTP_ADDR environment variable,
+//     then 127.0.0.1:8500 for the host and path prefix
+//   - consul_cluster_scheme, then the scheme of the address above, then https
+//     when the CONSUL_HTTP_SSL environment variable is true, then http for the scheme
+func resolveConsulAddress(clusterModel ConsulClusterModel) (consulEndpoint, error) {
+	rawAddress := defaultConsulAddress
+
+	if !clusterModel.ConsulClusterAddress.IsNull() {
+		rawAddress = clusterModel.ConsulClusterAddress.ValueString()
+	} else if envAddress := clusterModel.getenv(api.HTTPAddrEnvName); envAddress != "" {
+		rawAddress = envAddress
+	}
+
+	scheme := clusterModel.ConsulClusterScheme.ValueString()
+
+	// A socket path is not a valid host, so it is not parsed as one
+	if scheme == "unix" {
+		return consulEndpoint{Scheme: scheme, Host: strings.TrimPrefix(rawAddress, "unix://")}, nil
+	}
+
+	address, err := parseConsulAddress(rawAddress)
+
+	if err != nil {
+		return address, err
+	}
+
+	switch {
+	case scheme != "":
+		address.Scheme = scheme
+	case address.Scheme != "":
+	default:
+		address.Scheme = "http"
+
+		if ssl, _ := strconv.ParseBool(clusterModel.getenv(api.HTTPSSLEnvName)); ssl {
+			address.Scheme = "https"
+		}
+	}
+
+	switch address.Scheme {
+	case "http", "https", "unix":
+		return address, nil
+	}
+
+	return address, fmt.Errorf("invalid consul_cluster_scheme %q, expected http, https or unix", address.Scheme)
+}
+
+// withBaseTransport returns a copy of httpClient whose connections are made
+// by a copy of its transport, changed by configure.
+func withBaseTransport(httpClient *http.Client, configure func(transport *http.Transport)) *http.Client {
+	newClient := *httpClient
+
+	// Keep retrying requests, only their connections change
+	if retry, ok := httpClient.Transport.(*retryTransport); ok {
+		newRetry := *retry
+		newRetry.base = cloneTransport(retry.base)
+		configure(newRetry.base.(*http.Transport))
+		newClient.Transport = &newRetry
+	} else {
+		transport := cloneTransport(httpClient.Transport)
+		configure(transport)
+		newClient.Transport = transport
+	}
+
+	return &newClient
+}
+
+// cloneTransport returns a copy of roundTripper, or of the default transport
+// when it is not an *http.Transport.
+func cloneTransport(roundTripper http.RoundTripper) *http.Transport {
+	if transport, ok := roundTripper.(*http.Transport); ok {
+		return transport.Clone()
+	}
+
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// withUnixSocket returns a copy of httpClient sending its requests over the
+// unix socket at socketPath.
+func withUnixSocket(httpClient *http.Client, socketPath string) *http.Client {
+	var dialer net.Dialer
+
+	return withBaseTransport(httpClient, func(transport *http.Transport) {
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+	})
+}
+
+// resolveHttpAuth returns the basic auth credentials of http_auth, or of the
+// CONSUL_HTTP_AUTH environment variable, formatted as username[:password].
+func resolveHttpAuth(clusterModel ConsulClusterModel) *api.HttpBasicAuth {
+	auth := clusterModel.HttpAuth.ValueString()
+
+	if clusterModel.HttpAuth.IsNull() {
+		auth = clusterModel.getenv(api.HTTPAuthEnvName)
+	}
+
+	if auth == "" {
+		return nil
+	}
+
+	username, password, _ := strings.Cut(auth, ":")
+
+	return &api.HttpBasicAuth{
+		Username: username,
+		Password: password,
+	}
+}
+
+// consulHeaders returns the headers sent with each request to the consul cluster.
+func consulHeaders(clusterModel ConsulClusterModel) http.Header {
+	headers := make(http.Header)
+
+	for name, value := range clusterModel.Headers {
+		headers.Set(name, value.ValueString())
+	}
+
+	return headers
+}
+
+// newConsulClient creates a consul client sending headers with each request.
+func newConsulClient(consulConfig *api.Config, headers http.Header) (*api.Client, error) {
+	client, err := api.NewClient(consulConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetHeaders(headers)
+
+	return client, nil
+}
@@ -6,14 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	api "github.com/hashicorp/consul/api"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,32 +22,70 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ConsulExportedServiceResource{}
 var _ resource.ResourceWithImportState = &ConsulExportedServiceResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulExportedServiceResource{}
 
-// Allows for modification of exported-service only once at a time
-var exportedServiceLock sync.Mutex
+func newExportedServices() *api.ExportedServicesConfigEntry {
+	return &api.ExportedServicesConfigEntry{
+		Name: "default",
+	}
+}
 
-func readExportedServices(client *api.Client) *api.ExportedServicesConfigEntry {
-	configEntry, _, err := client.ConfigEntries().Get("exported-services", "default", nil)
+func exportedServicesIsEmpty(configEntry *api.ExportedServicesConfigEntry) bool {
+	return len(configEntry.Services) == 0
+}
+
+// readExportedServices returns the exported services, which are empty when
+// the entry does not exist. The other errors are returned, so that a denied
+// or failed read is never mistaken for nothing being exported.
+func readExportedServices(client *api.Client) (*api.ExportedServicesConfigEntry, error) {
+	configEntry, found, err := readTypedConfigEntry[*api.ExportedServicesConfigEntry](client, api.ExportedServices, "default")
 
 	if err != nil {
-		return &api.ExportedServicesConfigEntry{
-			Name: "default",
-		}
+		return nil, err
 	}
 
-	return configEntry.(*api.ExportedServicesConfigEntry)
+	if !found {
+		return newExportedServices(), nil
+	}
+
+	return configEntry, nil
 }
 
-func writeExportedServices(client *api.Client, configEntry *api.ExportedServicesConfigEntry) error {
-	var err error
+// updateExportedServices applies update to the exported services, trying again
+// when another writer modified them concurrently.
+func updateExportedServices(ctx context.Context, client *api.Client, retryPolicy RetryPolicy, update func(configEntry *api.ExportedServicesConfigEntry)) error {
+	return updateConfigEntryFragment(ctx, client, retryPolicy, api.ExportedServices, "default", newExportedServices, exportedServicesIsEmpty, func(configEntry *api.ExportedServicesConfigEntry) error {
+		update(configEntry)
+		return nil
+	})
+}
+
+// addExportedService exports serviceToExport to the peer consumerToAdd,
+// unless it is already exported to it.
+func addExportedService(exportedServiceConfigEntry *api.ExportedServicesConfigEntry, serviceToExport, consumerToAdd string) {
+	newConsumer := api.ServiceConsumer{
+		Peer: consumerToAdd,
+	}
+
+	for idx := range exportedServiceConfigEntry.Services {
+		if exportedServiceConfigEntry.Services[idx].Name == serviceToExport {
+			for _, consumer := range exportedServiceConfigEntry.Services[idx].Consumers {
+				if consumer.Peer == consumerToAdd {
+					return
+				}
+			}
 
-	if len(configEntry.Services) == 0 {
-		_, err = client.ConfigEntries().Delete("exported-services", "default", nil)
-	} else {
-		_, _, err = client.ConfigEntries().Set(configEntry, nil)
+			exportedServiceConfigEntry.Services[idx].Consumers = append(exportedServiceConfigEntry.Services[idx].Consumers, newConsumer)
+			return
+		}
 	}
 
-	return err
+	exportedServiceConfigEntry.Services = append(exportedServiceConfigEntry.Services, api.ExportedService{
+		Name: serviceToExport,
+		Consumers: []api.ServiceConsumer{
+			newConsumer,
+		},
+	})
 }
 
 func NewConsulExportedServiceResource() resource.Resource {
@@ -56,13 +94,17 @@ func NewConsulExportedServiceResource() resource.Resource {
 
 // ConsulExportedServiceResource defines the resource implementation.
 type ConsulExportedServiceResource struct {
-	client *api.Client
+	providerData *UtilsProviderData
 }
 
 // ConsulExportedServiceResourceModel describes the resource data model.
 type ConsulExportedServiceResourceModel struct {
+	Cluster         types.String `tfsdk:"cluster"`
 	PeerName        types.String `tfsdk:"peer_name"`
 	ServiceToExport types.String `tfsdk:"service_to_export"`
+	ValidatePeer    types.Bool   `tfsdk:"validate_peer"`
+	WaitForActive   types.Bool   `tfsdk:"wait_for_active"`
+	WaitTimeout     types.String `tfsdk:"wait_for_active_timeout"`
 	Id              types.String `tfsdk:"id"`
 }
 
@@ -76,6 +118,13 @@ func (r *ConsulExportedServiceResource) Schema(ctx context.Context, req resource
 		MarkdownDescription: "Consul exported service resource",
 
 		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"peer_name": schema.StringAttribute{
 				MarkdownDescription: "Name of the peer to export the service to",
 				Required:            true,
@@ -90,6 +139,24 @@ func (r *ConsulExportedServiceResource) Schema(ctx context.Context, req resource
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"validate_peer": schema.BoolAttribute{
+				MarkdownDescription: "Whether to check that the peering exists before exporting the service",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the peering to be `ACTIVE` before exporting the service. Implies `validate_peer`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_active_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the peering to become `ACTIVE`, as a Go duration string. Defaults to `5m`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultPeeringWaitTimeout),
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Exported peer identifier",
@@ -101,26 +168,28 @@ func (r *ConsulExportedServiceResource) Schema(ctx context.Context, req resource
 	}
 }
 
+func (r *ConsulExportedServiceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateWaitTimeout(ctx, req.Config, &resp.Diagnostics)
+}
+
 func (r *ConsulExportedServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	createClient := req.ProviderData.(func(diagnostics *diag.Diagnostics) (*api.Client, error))
-
-	client, err := createClient(&resp.Diagnostics)
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
 
-	if err != nil {
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
 }
 
 func (r *ConsulExportedServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -133,34 +202,23 @@ func (r *ConsulExportedServiceResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	exportedServiceLock.Lock()
-	defer exportedServiceLock.Unlock()
-
-	exportedServiceConfigEntry := readExportedServices(r.client)
-
-	inserted := false
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
 
-	newConsumer := api.ServiceConsumer{
-		Peer: data.PeerName.ValueString(),
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
 	}
 
-	for idx := range exportedServiceConfigEntry.Services {
-		if exportedServiceConfigEntry.Services[idx].Name == data.ServiceToExport.ValueString() {
-			exportedServiceConfigEntry.Services[idx].Consumers = append(exportedServiceConfigEntry.Services[idx].Consumers, newConsumer)
-			inserted = true
-		}
-	}
+	err = checkPeering(ctx, client, data.PeerName.ValueString(), data.ValidatePeer, data.WaitForActive, data.WaitTimeout)
 
-	if !inserted {
-		exportedServiceConfigEntry.Services = append(exportedServiceConfigEntry.Services, api.ExportedService{
-			Name: data.ServiceToExport.ValueString(),
-			Consumers: []api.ServiceConsumer{
-				newConsumer,
-			},
-		})
+	if err != nil {
+		resp.Diagnostics.AddError("Peering Error", fmt.Sprintf("Unable to export service to peer, got error: %s", err))
+		return
 	}
 
-	err := writeExportedServices(r.client, exportedServiceConfigEntry)
+	err = updateExportedServices(ctx, client, r.providerData.RetryPolicy, func(exportedServiceConfigEntry *api.ExportedServicesConfigEntry) {
+		addExportedService(exportedServiceConfigEntry, data.ServiceToExport.ValueString(), data.PeerName.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write exported services, got error: %s", err))
@@ -184,7 +242,19 @@ func (r *ConsulExportedServiceResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	exportedServiceConfigEntry := readExportedServices(r.client)
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	exportedServiceConfigEntry, err := readExportedServices(client)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read exported services, got error: %s", err))
+		return
+	}
 
 	for _, service := range exportedServiceConfigEntry.Services {
 		if service.Name == data.ServiceToExport.ValueString() {
@@ -215,36 +285,24 @@ func (r *ConsulExportedServiceResource) Update(ctx context.Context, req resource
 		return
 	}
 
-	exportedServiceLock.Lock()
-	defer exportedServiceLock.Unlock()
-
-	exportedServiceConfigEntry := readExportedServices(r.client)
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
 
-	removeExportedService(exportedServiceConfigEntry, oldData.ServiceToExport.ValueString(), oldData.PeerName.ValueString())
-
-	newConsumer := api.ServiceConsumer{
-		Peer: data.PeerName.ValueString(),
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
 	}
 
-	inserted := false
-
-	for idx := range exportedServiceConfigEntry.Services {
-		if exportedServiceConfigEntry.Services[idx].Name == data.ServiceToExport.ValueString() {
-			exportedServiceConfigEntry.Services[idx].Consumers = append(exportedServiceConfigEntry.Services[idx].Consumers, newConsumer)
-			inserted = true
-		}
-	}
+	err = checkPeering(ctx, client, data.PeerName.ValueString(), data.ValidatePeer, data.WaitForActive, data.WaitTimeout)
 
-	if !inserted {
-		exportedServiceConfigEntry.Services = append(exportedServiceConfigEntry.Services, api.ExportedService{
-			Name: data.ServiceToExport.ValueString(),
-			Consumers: []api.ServiceConsumer{
-				newConsumer,
-			},
-		})
+	if err != nil {
+		resp.Diagnostics.AddError("Peering Error", fmt.Sprintf("Unable to export service to peer, got error: %s", err))
+		return
 	}
 
-	err := writeExportedServices(r.client, exportedServiceConfigEntry)
+	err = updateExportedServices(ctx, client, r.providerData.RetryPolicy, func(exportedServiceConfigEntry *api.ExportedServicesConfigEntry) {
+		removeExportedService(exportedServiceConfigEntry, oldData.ServiceToExport.ValueString(), oldData.PeerName.ValueString())
+		addExportedService(exportedServiceConfigEntry, data.ServiceToExport.ValueString(), data.PeerName.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write exported services, got error: %s", err))
@@ -268,14 +326,16 @@ func (r *ConsulExportedServiceResource) Delete(ctx context.Context, req resource
 		return
 	}
 
-	exportedServiceLock.Lock()
-	defer exportedServiceLock.Unlock()
-
-	exportedServiceConfigEntry := readExportedServices(r.client)
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
 
-	removeExportedService(exportedServiceConfigEntry, data.ServiceToExport.ValueString(), data.PeerName.ValueString())
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
 
-	err := writeExportedServices(r.client, exportedServiceConfigEntry)
+	err = updateExportedServices(ctx, client, r.providerData.RetryPolicy, func(exportedServiceConfigEntry *api.ExportedServicesConfigEntry) {
+		removeExportedService(exportedServiceConfigEntry, data.ServiceToExport.ValueString(), data.PeerName.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write exported services, got error: %s", err))
@@ -285,31 +345,31 @@ func (r *ConsulExportedServiceResource) Delete(ctx context.Context, req resource
 	resp.State.RemoveResource(ctx)
 }
 
-func removeExportedService(exportedServiceConfigEntry *api.ExportedServicesConfigEntry, serviceToRremove, consumerToRemove string) {
-	var serviceToRemoveIdx int
-
-	for idx_services, service := range exportedServiceConfigEntry.Services {
-		if service.Name == serviceToRremove {
-			serviceToRemoveIdx = idx_services
-			break
+// removeExportedService stops exporting serviceToRemove to the peer
+// consumerToRemove, removing the service once it has no consumer left.
+func removeExportedService(exportedServiceConfigEntry *api.ExportedServicesConfigEntry, serviceToRemove, consumerToRemove string) {
+	for i, service := range exportedServiceConfigEntry.Services {
+		if service.Name != serviceToRemove {
+			continue
 		}
-	}
 
-	var consumerToRemoveIdx int
+		for j, consumer := range service.Consumers {
+			if consumer.Peer != consumerToRemove {
+				continue
+			}
 
-	for idx_consumers, consumer := range exportedServiceConfigEntry.Services[serviceToRemoveIdx].Consumers {
-		if consumer.Peer == consumerToRemove {
-			consumerToRemoveIdx = idx_consumers
-			break
-		}
-	}
+			service.Consumers = append(service.Consumers[:j], service.Consumers[j+1:]...)
 
-	newConsumers := append(exportedServiceConfigEntry.Services[serviceToRemoveIdx].Consumers[:consumerToRemoveIdx], exportedServiceConfigEntry.Services[serviceToRemoveIdx].Consumers[consumerToRemoveIdx+1:]...)
+			if len(service.Consumers) == 0 {
+				exportedServiceConfigEntry.Services = append(exportedServiceConfigEntry.Services[:i], exportedServiceConfigEntry.Services[i+1:]...)
+			} else {
+				exportedServiceConfigEntry.Services[i].Consumers = service.Consumers
+			}
 
-	if len(newConsumers) == 0 {
-		exportedServiceConfigEntry.Services = append(exportedServiceConfigEntry.Services[:serviceToRemoveIdx], exportedServiceConfigEntry.Services[serviceToRemoveIdx+1:]...)
-	} else {
-		exportedServiceConfigEntry.Services[serviceToRemoveIdx].Consumers = newConsumers
+			return
+		}
+
+		return
 	}
 }
 
@@ -5,8 +5,13 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
+	api "github.com/hashicorp/consul/api"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -103,3 +108,90 @@ resource "utils_consul_exported_service" "test2" {
 }
 `, configurableAttribute)
 }
+
+func TestAccConsulExportedServiceResourceValidatePeer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utils_consul_exported_service" "test" {
+	peer_name = "invalid-peer"
+	service_to_export = "invalid-service"
+	validate_peer = true
+}
+`,
+				ExpectError: regexp.MustCompile(`peering "invalid-peer" does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccConsulExportedServiceResourceInvalidWaitTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utils_consul_exported_service" "test" {
+	peer_name = "invalid-peer"
+	service_to_export = "invalid-service"
+	wait_for_active = true
+	wait_for_active_timeout = "5 minutes"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+		},
+	})
+}
+
+func TestRemoveExportedService(t *testing.T) {
+	configEntry := newExportedServices()
+
+	// Removing from an entry without services leaves it untouched
+	removeExportedService(configEntry, "web", "peer")
+
+	addExportedService(configEntry, "web", "peer")
+	addExportedService(configEntry, "web", "other-peer")
+	addExportedService(configEntry, "api", "peer")
+
+	removeExportedService(configEntry, "web", "unknown-peer")
+	removeExportedService(configEntry, "unknown", "peer")
+
+	if len(configEntry.Services) != 2 || len(configEntry.Services[0].Consumers) != 2 {
+		t.Fatalf("expected the missing service and consumer not to remove anything, got %+v", configEntry.Services)
+	}
+
+	removeExportedService(configEntry, "web", "other-peer")
+
+	if consumers := configEntry.Services[0].Consumers; len(consumers) != 1 || consumers[0].Peer != "peer" {
+		t.Errorf("expected only the other peer to be removed, got %+v", consumers)
+	}
+
+	removeExportedService(configEntry, "web", "peer")
+
+	if len(configEntry.Services) != 1 || configEntry.Services[0].Name != "api" {
+		t.Errorf("expected the service to be removed with its last consumer, got %+v", configEntry.Services)
+	}
+}
+
+func TestReadExportedServicesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(server.URL, "http://")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readExportedServices(client); err == nil {
+		t.Error("expected a failed read to fail rather than return no exported services")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConsulExportedServicesDataSource{}
+
+func NewConsulExportedServicesDataSource() datasource.DataSource {
+	return &ConsulExportedServicesDataSource{}
+}
+
+// ConsulExportedServicesDataSource defines the data source implementation.
+type ConsulExportedServicesDataSource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulExportedServicesDataSourceModel describes the data source data model.
+type ConsulExportedServicesDataSourceModel struct {
+	Cluster        types.String                 `tfsdk:"cluster"`
+	Services       []ConsulExportedServiceModel `tfsdk:"services"`
+	ServicesByPeer map[string][]types.String    `tfsdk:"services_by_peer"`
+}
+
+// ConsulExportedServiceModel describes a single exported service.
+type ConsulExportedServiceModel struct {
+	Name      types.String                 `tfsdk:"name"`
+	Namespace types.String                 `tfsdk:"namespace"`
+	Consumers []ConsulServiceConsumerModel `tfsdk:"consumers"`
+}
+
+// ConsulServiceConsumerModel describes a consumer of an exported service.
+type ConsulServiceConsumerModel struct {
+	Peer          types.String `tfsdk:"peer"`
+	Partition     types.String `tfsdk:"partition"`
+	SamenessGroup types.String `tfsdk:"sameness_group"`
+}
+
+func (d *ConsulExportedServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_exported_services"
+}
+
+func (d *ConsulExportedServicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads the `default` exported-services config entry.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+			},
+			"services": schema.ListNestedAttribute{
+				MarkdownDescription: "The exported services",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the exported service",
+							Computed:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "The namespace the service is exported from",
+							Computed:            true,
+						},
+						"consumers": schema.ListNestedAttribute{
+							MarkdownDescription: "The consumers the service is exported to",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"peer": schema.StringAttribute{
+										MarkdownDescription: "The name of the peer the service is exported to",
+										Computed:            true,
+									},
+									"partition": schema.StringAttribute{
+										MarkdownDescription: "The name of the partition the service is exported to",
+										Computed:            true,
+									},
+									"sameness_group": schema.StringAttribute{
+										MarkdownDescription: "The name of the sameness group the service is exported to",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"services_by_peer": schema.MapAttribute{
+				MarkdownDescription: "The names of the exported services keyed by the peer they are exported to",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConsulExportedServicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ConsulExportedServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConsulExportedServicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	exportedServiceConfigEntry, err := readExportedServices(client)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read exported services, got error: %s", err))
+		return
+	}
+
+	data.Services = make([]ConsulExportedServiceModel, 0, len(exportedServiceConfigEntry.Services))
+	data.ServicesByPeer = make(map[string][]types.String)
+
+	for _, service := range exportedServiceConfigEntry.Services {
+		serviceModel := ConsulExportedServiceModel{
+			Name:      types.StringValue(service.Name),
+			Namespace: types.StringValue(service.Namespace),
+			Consumers: make([]ConsulServiceConsumerModel, 0, len(service.Consumers)),
+		}
+
+		for _, consumer := range service.Consumers {
+			serviceModel.Consumers = append(serviceModel.Consumers, ConsulServiceConsumerModel{
+				Peer:          types.StringValue(consumer.Peer),
+				Partition:     types.StringValue(consumer.Partition),
+				SamenessGroup: types.StringValue(consumer.SamenessGroup),
+			})
+
+			if consumer.Peer != "" {
+				data.ServicesByPeer[consumer.Peer] = append(data.ServicesByPeer[consumer.Peer], types.StringValue(service.Name))
+			}
+		}
+
+		data.Services = append(data.Services, serviceModel)
+	}
+
+	tflog.Debug(ctx, "read exported services")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
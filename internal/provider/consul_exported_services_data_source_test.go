@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulExportedServicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConsulExportedServicesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utils_consul_exported_services.test", "services.#", "1"),
+					resource.TestCheckResourceAttr("data.utils_consul_exported_services.test", "services.0.name", "invalid-service-datasource"),
+					resource.TestCheckResourceAttr("data.utils_consul_exported_services.test", "services.0.consumers.0.peer", "invalid-peer"),
+					resource.TestCheckResourceAttr("data.utils_consul_exported_services.test", "services_by_peer.invalid-peer.0", "invalid-service-datasource"),
+				),
+			},
+		},
+	})
+}
+
+const testAccConsulExportedServicesDataSourceConfig = `
+resource "utils_consul_exported_service" "test" {
+	peer_name = "invalid-peer"
+	service_to_export = "invalid-service-datasource"
+}
+
+data "utils_consul_exported_services" "test" {
+	depends_on = [utils_consul_exported_service.test]
+}
+`
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+
+	api "github.com/hashicorp/consul/api"
+)
+
+const defaultIngressListenerProtocol = "tcp"
+
+func newIngressGateway(gateway string) func() *api.IngressGatewayConfigEntry {
+	return func() *api.IngressGatewayConfigEntry {
+		return &api.IngressGatewayConfigEntry{
+			Kind: api.IngressGateway,
+			Name: gateway,
+		}
+	}
+}
+
+// ingressGatewayIsEmpty tells whether no listener nor gateway-wide setting of the ingress-gateway entry is left.
+func ingressGatewayIsEmpty(configEntry *api.IngressGatewayConfigEntry) bool {
+	remaining := *configEntry
+	remaining.CreateIndex = 0
+	remaining.ModifyIndex = 0
+
+	if len(remaining.Listeners) == 0 {
+		remaining.Listeners = nil
+	}
+
+	if len(remaining.Meta) == 0 {
+		remaining.Meta = nil
+	}
+
+	return reflect.DeepEqual(remaining, api.IngressGatewayConfigEntry{
+		Kind:      configEntry.Kind,
+		Name:      configEntry.Name,
+		Partition: configEntry.Partition,
+		Namespace: configEntry.Namespace,
+	})
+}
+
+// findIngressListener returns the index of the listener on port, or -1 when there is none.
+func findIngressListener(configEntry *api.IngressGatewayConfigEntry, port int) int {
+	for i, listener := range configEntry.Listeners {
+		if listener.Port == port {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findIngressService returns the index of the service in listener, or -1 when there is none.
+func findIngressService(listener *api.IngressListener, service api.IngressService) int {
+	for i, other := range listener.Services {
+		if other.Name == service.Name && other.Namespace == service.Namespace && other.Partition == service.Partition {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// addIngressService adds service to the listener on port, creating the
+// listener with protocol when the gateway has none on this port yet. A service
+// already on the listener is only replaced when replace is set, as on create
+// it belongs to another owner.
+func addIngressService(configEntry *api.IngressGatewayConfigEntry, port int, protocol string, service api.IngressService, replace bool) error {
+	i := findIngressListener(configEntry, port)
+
+	if i == -1 {
+		configEntry.Listeners = append(configEntry.Listeners, api.IngressListener{
+			Port:     port,
+			Protocol: protocol,
+		})
+
+		i = len(configEntry.Listeners) - 1
+	}
+
+	listener := &configEntry.Listeners[i]
+
+	if listener.Protocol != protocol {
+		return fmt.Errorf("the listener on port %d of ingress-gateway %q uses protocol %q, not %q", port, configEntry.Name, listener.Protocol, protocol)
+	}
+
+	if j := findIngressService(listener, service); j != -1 {
+		if !replace {
+			return fmt.Errorf("the service %q is already attached to the listener on port %d of ingress-gateway %q", service.Name, port, configEntry.Name)
+		}
+
+		listener.Services[j] = service
+		return nil
+	}
+
+	listener.Services = append(listener.Services, service)
+
+	return nil
+}
+
+// removeIngressService removes service from the listener on port, and the
+// listener itself once it has no service left.
+func removeIngressService(configEntry *api.IngressGatewayConfigEntry, port int, service api.IngressService) {
+	i := findIngressListener(configEntry, port)
+
+	if i == -1 {
+		return
+	}
+
+	listener := &configEntry.Listeners[i]
+	j := findIngressService(listener, service)
+
+	if j == -1 {
+		return
+	}
+
+	listener.Services = append(listener.Services[:j], listener.Services[j+1:]...)
+
+	if len(listener.Services) == 0 {
+		configEntry.Listeners = append(configEntry.Listeners[:i], configEntry.Listeners[i+1:]...)
+	}
+}
+
+func newTerminatingGateway(gateway string) func() *api.TerminatingGatewayConfigEntry {
+	return func() *api.TerminatingGatewayConfigEntry {
+		return &api.TerminatingGatewayConfigEntry{
+			Kind: api.TerminatingGateway,
+			Name: gateway,
+		}
+	}
+}
+
+func terminatingGatewayIsEmpty(configEntry *api.TerminatingGatewayConfigEntry) bool {
+	return len(configEntry.Services) == 0 && len(configEntry.Meta) == 0
+}
+
+// findLinkedService returns the index of the linked service, or -1 when there is none.
+func findLinkedService(configEntry *api.TerminatingGatewayConfigEntry, service api.LinkedService) int {
+	for i, other := range configEntry.Services {
+		if other.Name == service.Name && other.Namespace == service.Namespace {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// addLinkedService links service to the terminating gateway. A service
+// already linked is only replaced when replace is set, as on create it
+// belongs to another owner.
+func addLinkedService(configEntry *api.TerminatingGatewayConfigEntry, service api.LinkedService, replace bool) error {
+	if i := findLinkedService(configEntry, service); i != -1 {
+		if !replace {
+			return fmt.Errorf("the service %q is already linked to terminating-gateway %q", service.Name, configEntry.Name)
+		}
+
+		configEntry.Services[i] = service
+		return nil
+	}
+
+	configEntry.Services = append(configEntry.Services, service)
+
+	return nil
+}
+
+// removeLinkedService unlinks service from the terminating gateway.
+func removeLinkedService(configEntry *api.TerminatingGatewayConfigEntry, service api.LinkedService) {
+	if i := findLinkedService(configEntry, service); i != -1 {
+		configEntry.Services = append(configEntry.Services[:i], configEntry.Services[i+1:]...)
+	}
+}
@@ -0,0 +1,536 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulHTTPRouteRuleResource{}
+
+func NewConsulHTTPRouteRuleResource() resource.Resource {
+	return &ConsulHTTPRouteRuleResource{}
+}
+
+// ConsulHTTPRouteRuleResource defines the resource implementation.
+type ConsulHTTPRouteRuleResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulHTTPRouteRuleResourceModel describes the resource data model.
+type ConsulHTTPRouteRuleResourceModel struct {
+	Cluster        types.String                      `tfsdk:"cluster"`
+	Route          types.String                      `tfsdk:"route"`
+	Match          []ConsulHTTPRouteRuleMatchModel   `tfsdk:"match"`
+	Service        []ConsulHTTPRouteRuleServiceModel `tfsdk:"service"`
+	URLRewritePath types.String                      `tfsdk:"url_rewrite_path"`
+	StatusTimeout  types.String                      `tfsdk:"status_timeout"`
+	Id             types.String                      `tfsdk:"id"`
+}
+
+// ConsulHTTPRouteRuleMatchModel describes the requests matched by a rule.
+type ConsulHTTPRouteRuleMatchModel struct {
+	PathMatch types.String                         `tfsdk:"path_match"`
+	Path      types.String                         `tfsdk:"path"`
+	Method    types.String                         `tfsdk:"method"`
+	Header    []ConsulHTTPRouteRuleMatchValueModel `tfsdk:"header"`
+	Query     []ConsulHTTPRouteRuleMatchValueModel `tfsdk:"query"`
+}
+
+// ConsulHTTPRouteRuleMatchValueModel describes a header or a query parameter matched by a rule.
+type ConsulHTTPRouteRuleMatchValueModel struct {
+	Match types.String `tfsdk:"match"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// ConsulHTTPRouteRuleServiceModel describes a service the requests matched by a rule are sent to.
+type ConsulHTTPRouteRuleServiceModel struct {
+	Name      types.String `tfsdk:"name"`
+	Weight    types.Int64  `tfsdk:"weight"`
+	Namespace types.String `tfsdk:"namespace"`
+	Partition types.String `tfsdk:"partition"`
+}
+
+func (r *ConsulHTTPRouteRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_http_route_rule"
+}
+
+func matchValueSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"match": schema.StringAttribute{
+					MarkdownDescription: "How the value is matched, either `exact`, `prefix`, `suffix`, `regex` or `present`",
+					Required:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The name of the value",
+					Required:            true,
+				},
+				"value": schema.StringAttribute{
+					MarkdownDescription: "The value matched",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulHTTPRouteRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Adds a single rule to an existing `http-route` config entry, so that several teams can route their own requests through a shared route, then waits until consul reports the route as accepted. The conditions reported before the write are only trusted after " + routeStatusSettleTime.String() + ", the time for consul to update them. The rule is identified by its matches, and creating the resource fails when a rule with the same matches already exists.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"route": schema.StringAttribute{
+				MarkdownDescription: "The name of the `http-route` entry",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"match": schema.ListNestedAttribute{
+				MarkdownDescription: "The requests matched by the rule, any of them being enough. Every request is matched when unset.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path_match": schema.StringAttribute{
+							MarkdownDescription: "How the path is matched, either `exact`, `prefix` or `regex`. Defaults to `prefix`.",
+							Optional:            true,
+						},
+						"path": schema.StringAttribute{
+							MarkdownDescription: "The path matched. Defaults to `/`.",
+							Optional:            true,
+						},
+						"method": schema.StringAttribute{
+							MarkdownDescription: "The HTTP method of the requests",
+							Optional:            true,
+						},
+						"header": matchValueSchema("The headers of the requests"),
+						"query":  matchValueSchema("The query parameters of the requests"),
+					},
+				},
+			},
+			"service": schema.ListNestedAttribute{
+				MarkdownDescription: "The services the requests are sent to",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the service",
+							Required:            true,
+						},
+						"weight": schema.Int64Attribute{
+							MarkdownDescription: "The share of the requests sent to the service, relative to the other services. Defaults to 1.",
+							Optional:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "The namespace of the service",
+							Optional:            true,
+						},
+						"partition": schema.StringAttribute{
+							MarkdownDescription: "The admin partition of the service",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"url_rewrite_path": schema.StringAttribute{
+				MarkdownDescription: "The path replacing the matched prefix before the requests are sent to the services",
+				Optional:            true,
+			},
+			"status_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for consul to report the route as accepted, as a Go duration string. Defaults to `" + defaultRouteStatusTimeout + "`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultRouteStatusTimeout),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "HTTP route rule identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulHTTPRouteRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// matches returns the matches of the rule as consul normalizes them, so that
+// they keep the same fingerprint once written.
+func (data ConsulHTTPRouteRuleResourceModel) matches() []api.HTTPMatch {
+	var matches []api.HTTPMatch
+
+	for _, match := range data.Match {
+		httpMatch := api.HTTPMatch{
+			Method: api.HTTPMatchMethod(strings.ToUpper(match.Method.ValueString())),
+			Path: api.HTTPPathMatch{
+				Match: api.HTTPPathMatchType(match.PathMatch.ValueString()),
+				Value: match.Path.ValueString(),
+			},
+		}
+
+		if httpMatch.Path.Match == "" {
+			httpMatch.Path = api.HTTPPathMatch{
+				Match: api.HTTPPathMatchPrefix,
+				Value: "/",
+			}
+		}
+
+		for _, header := range match.Header {
+			httpMatch.Headers = append(httpMatch.Headers, api.HTTPHeaderMatch{
+				Match: api.HTTPHeaderMatchType(header.Match.ValueString()),
+				Name:  header.Name.ValueString(),
+				Value: header.Value.ValueString(),
+			})
+		}
+
+		for _, query := range match.Query {
+			httpMatch.Query = append(httpMatch.Query, api.HTTPQueryMatch{
+				Match: api.HTTPQueryMatchType(query.Match.ValueString()),
+				Name:  query.Name.ValueString(),
+				Value: query.Value.ValueString(),
+			})
+		}
+
+		matches = append(matches, httpMatch)
+	}
+
+	return matches
+}
+
+func (data ConsulHTTPRouteRuleResourceModel) rule() api.HTTPRouteRule {
+	rule := api.HTTPRouteRule{
+		Matches: data.matches(),
+	}
+
+	for _, service := range data.Service {
+		rule.Services = append(rule.Services, api.HTTPService{
+			Name:      service.Name.ValueString(),
+			Weight:    int(service.Weight.ValueInt64()),
+			Namespace: service.Namespace.ValueString(),
+			Partition: service.Partition.ValueString(),
+		})
+	}
+
+	if !data.URLRewritePath.IsNull() {
+		rule.Filters.URLRewrite = &api.URLRewrite{
+			Path: data.URLRewritePath.ValueString(),
+		}
+	}
+
+	return rule
+}
+
+// readRule sets data to the rule written in the route.
+func (data *ConsulHTTPRouteRuleResourceModel) readRule(rule api.HTTPRouteRule) {
+	services := make([]ConsulHTTPRouteRuleServiceModel, 0, len(rule.Services))
+
+	for i, service := range rule.Services {
+		weight := types.Int64Value(int64(service.Weight))
+
+		// Consul sets the default weight of the services to 1
+		if service.Weight <= 1 && i < len(data.Service) && data.Service[i].Weight.IsNull() {
+			weight = types.Int64Null()
+		}
+
+		services = append(services, ConsulHTTPRouteRuleServiceModel{
+			Name:      types.StringValue(service.Name),
+			Weight:    weight,
+			Namespace: optionalString(service.Namespace),
+			Partition: optionalString(service.Partition),
+		})
+	}
+
+	data.Service = services
+	data.URLRewritePath = types.StringNull()
+
+	if rule.Filters.URLRewrite != nil {
+		data.URLRewritePath = types.StringValue(rule.Filters.URLRewrite.Path)
+	}
+}
+
+func (data ConsulHTTPRouteRuleResourceModel) fingerprint() string {
+	return httpRouteRuleFingerprint(data.matches())
+}
+
+func (data ConsulHTTPRouteRuleResourceModel) id() string {
+	return fmt.Sprintf("%s_%s", data.Route.ValueString(), data.fingerprint())
+}
+
+// typedRoute returns configEntry as an http-route entry, which must exist.
+func (data ConsulHTTPRouteRuleResourceModel) typedRoute(configEntry api.ConfigEntry) (*api.HTTPRouteConfigEntry, error) {
+	if configEntry == nil {
+		return nil, fmt.Errorf("%s %q does not exist", api.HTTPRoute, data.Route.ValueString())
+	}
+
+	route, ok := configEntry.(*api.HTTPRouteConfigEntry)
+
+	if !ok {
+		return nil, fmt.Errorf("config entry %s/%s has unexpected type %T", api.HTTPRoute, data.Route.ValueString(), configEntry)
+	}
+
+	return route, nil
+}
+
+// setRule adds the rule to the route, or replaces the rule with the same matches.
+func (data ConsulHTTPRouteRuleResourceModel) setRule(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	route, err := data.typedRoute(configEntry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if i := findHTTPRouteRule(route, data.fingerprint()); i != -1 {
+		route.Rules[i] = data.rule()
+	} else {
+		route.Rules = append(route.Rules, data.rule())
+	}
+
+	return route, nil
+}
+
+// addRule adds the rule to the route, refusing a rule with the same matches,
+// as it belongs to another owner.
+func (data ConsulHTTPRouteRuleResourceModel) addRule(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	route, err := data.typedRoute(configEntry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if findHTTPRouteRule(route, data.fingerprint()) != -1 {
+		return nil, fmt.Errorf("a rule with the same matches already exists")
+	}
+
+	return data.setRule(route)
+}
+
+// removeRule removes the rule from the route.
+func (data ConsulHTTPRouteRuleResourceModel) removeRule(configEntry api.ConfigEntry) (api.ConfigEntry, error) {
+	// Nothing to remove from a deleted route
+	if configEntry == nil {
+		return nil, nil
+	}
+
+	route, err := data.typedRoute(configEntry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if i := findHTTPRouteRule(route, data.fingerprint()); i != -1 {
+		route.Rules = append(route.Rules[:i], route.Rules[i+1:]...)
+	}
+
+	return route, nil
+}
+
+func (r *ConsulHTTPRouteRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulHTTPRouteRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	timeout, err := parseOptionalDuration("status_timeout", data.StatusTimeout)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Status Timeout", err.Error())
+		return
+	}
+
+	written := time.Now()
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, api.HTTPRoute, data.Route.ValueString(), data.addRule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write http route, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "http route rule")
+
+	// The rule is written even when consul does not accept the route, so it is kept in the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	conditions, err := waitForRouteStatus(ctx, client, api.HTTPRoute, data.Route.ValueString(), nil, written, timeout)
+
+	if err != nil {
+		addRouteStatusDiagnostics(&resp.Diagnostics, conditions, err)
+	}
+}
+
+func (r *ConsulHTTPRouteRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulHTTPRouteRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	route, found, err := readTypedConfigEntry[*api.HTTPRouteConfigEntry](client, api.HTTPRoute, data.Route.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read http route, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	i := findHTTPRouteRule(route, data.fingerprint())
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.readRule(route.Rules[i])
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulHTTPRouteRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulHTTPRouteRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	timeout, err := parseOptionalDuration("status_timeout", data.StatusTimeout)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Status Timeout", err.Error())
+		return
+	}
+
+	written := time.Now()
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, api.HTTPRoute, data.Route.ValueString(), data.setRule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write http route, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "http route rule")
+
+	// The rule is written even when consul does not accept the route, so it is kept in the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	conditions, err := waitForRouteStatus(ctx, client, api.HTTPRoute, data.Route.ValueString(), nil, written, timeout)
+
+	if err != nil {
+		addRouteStatusDiagnostics(&resp.Diagnostics, conditions, err)
+	}
+}
+
+func (r *ConsulHTTPRouteRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulHTTPRouteRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntry(ctx, client, r.providerData.RetryPolicy, api.HTTPRoute, data.Route.ValueString(), data.removeRule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write http route, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
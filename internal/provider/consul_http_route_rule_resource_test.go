@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulHTTPRouteRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_http_route_rule" "test" {
+	route = "web"
+
+	match = [{
+		path_match = "prefix"
+		path       = "/billing"
+	}]
+
+	service = [{
+		name = "billing"
+	}]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_http_route_rule.test", "service.0.name", "billing"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_http_route_rule" "test" {
+	route = "web"
+
+	match = [{
+		path_match = "prefix"
+		path       = "/billing"
+	}]
+
+	service = [{
+		name = "billing"
+	}]
+
+	url_rewrite_path = "/"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_http_route_rule.test", "url_rewrite_path", "/"),
+				),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestHTTPRouteRuleFragments(t *testing.T) {
+	client, _ := newTestConfigEntryClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.ConfigEntries().Set(&api.HTTPRouteConfigEntry{Kind: api.HTTPRoute, Name: "web"}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	billing := ConsulHTTPRouteRuleResourceModel{
+		Route: types.StringValue("web"),
+		Match: []ConsulHTTPRouteRuleMatchModel{
+			{Path: types.StringValue("/billing"), PathMatch: types.StringValue("prefix"), Method: types.StringValue("get")},
+		},
+		Service: []ConsulHTTPRouteRuleServiceModel{
+			{Name: types.StringValue("billing"), Weight: types.Int64Null()},
+		},
+	}
+
+	// Every request is matched by the rule without matches
+	fallback := ConsulHTTPRouteRuleResourceModel{
+		Route: types.StringValue("web"),
+		Match: []ConsulHTTPRouteRuleMatchModel{{}},
+		Service: []ConsulHTTPRouteRuleServiceModel{
+			{Name: types.StringValue("web"), Weight: types.Int64Value(2)},
+		},
+	}
+
+	for _, data := range []ConsulHTTPRouteRuleResourceModel{billing, fallback} {
+		if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", data.addRule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The rule of another owner is only replaced on update
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", billing.addRule); err == nil {
+		t.Error("expected an error when adding a rule with the same matches")
+	}
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", billing.setRule); err != nil {
+		t.Fatal(err)
+	}
+
+	route, _, err := readTypedConfigEntry[*api.HTTPRouteConfigEntry](client, api.HTTPRoute, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(route.Rules) != 2 {
+		t.Fatalf("expected one rule per set of matches, got %+v", route.Rules)
+	}
+
+	if route.Rules[0].Matches[0].Method != "GET" || route.Rules[1].Matches[0].Path.Value != "/" {
+		t.Errorf("expected the matches to be normalized, got %+v", route.Rules)
+	}
+
+	read := billing
+	read.readRule(route.Rules[findHTTPRouteRule(route, billing.fingerprint())])
+
+	if !read.Service[0].Weight.IsNull() || !read.URLRewritePath.IsNull() {
+		t.Errorf("expected the unset attributes to stay unset, got %+v", read)
+	}
+
+	if err := updateConfigEntry(ctx, client, testRetryPolicy, api.HTTPRoute, "web", billing.removeRule); err != nil {
+		t.Fatal(err)
+	}
+
+	route, _, err = readTypedConfigEntry[*api.HTTPRouteConfigEntry](client, api.HTTPRoute, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(route.Rules) != 1 || route.Rules[0].Services[0].Name != "web" {
+		t.Errorf("expected only the fallback rule to be left, got %+v", route.Rules)
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulIngressGatewayServiceResource{}
+var _ resource.ResourceWithImportState = &ConsulIngressGatewayServiceResource{}
+
+func NewConsulIngressGatewayServiceResource() resource.Resource {
+	return &ConsulIngressGatewayServiceResource{}
+}
+
+// ConsulIngressGatewayServiceResource defines the resource implementation.
+type ConsulIngressGatewayServiceResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulIngressGatewayServiceResourceModel describes the resource data model.
+type ConsulIngressGatewayServiceResourceModel struct {
+	Cluster   types.String   `tfsdk:"cluster"`
+	Gateway   types.String   `tfsdk:"gateway"`
+	Port      types.Int64    `tfsdk:"port"`
+	Protocol  types.String   `tfsdk:"protocol"`
+	Service   types.String   `tfsdk:"service"`
+	Namespace types.String   `tfsdk:"namespace"`
+	Partition types.String   `tfsdk:"partition"`
+	Hosts     []types.String `tfsdk:"hosts"`
+	Id        types.String   `tfsdk:"id"`
+}
+
+func (r *ConsulIngressGatewayServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_ingress_gateway_service"
+}
+
+func (r *ConsulIngressGatewayServiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Attaches a single service to a listener of an `ingress-gateway` config entry, so that each team can expose its own service through a shared gateway. The listener is created with the first service attached to it and removed with the last one, and creating the resource fails when the service is already attached to the listener.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				MarkdownDescription: "The name of the ingress gateway",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "The port of the listener the service is attached to",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "The protocol of the listener, which must match the protocol of the listener when it already exists. Defaults to `tcp`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultIngressListenerProtocol),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The name of the service attached to the listener, or `*` for every service of an HTTP listener",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The namespace of the service",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"partition": schema.StringAttribute{
+				MarkdownDescription: "The admin partition of the service",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hosts": schema.ListAttribute{
+				MarkdownDescription: "The hosts routed to the service by an HTTP listener",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Ingress gateway service identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulIngressGatewayServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ingressService returns the service of data as listed by the gateway.
+func (data ConsulIngressGatewayServiceResourceModel) ingressService() api.IngressService {
+	service := api.IngressService{
+		Name:      data.Service.ValueString(),
+		Namespace: data.Namespace.ValueString(),
+		Partition: data.Partition.ValueString(),
+	}
+
+	for _, host := range data.Hosts {
+		service.Hosts = append(service.Hosts, host.ValueString())
+	}
+
+	return service
+}
+
+func (data ConsulIngressGatewayServiceResourceModel) addTo(configEntry *api.IngressGatewayConfigEntry) error {
+	return addIngressService(configEntry, int(data.Port.ValueInt64()), data.Protocol.ValueString(), data.ingressService(), false)
+}
+
+func (data ConsulIngressGatewayServiceResourceModel) updateIn(configEntry *api.IngressGatewayConfigEntry) error {
+	return addIngressService(configEntry, int(data.Port.ValueInt64()), data.Protocol.ValueString(), data.ingressService(), true)
+}
+
+func (data ConsulIngressGatewayServiceResourceModel) removeFrom(configEntry *api.IngressGatewayConfigEntry) error {
+	removeIngressService(configEntry, int(data.Port.ValueInt64()), data.ingressService())
+	return nil
+}
+
+func (data ConsulIngressGatewayServiceResourceModel) id() string {
+	id := fmt.Sprintf("%s_%d_%s", data.Gateway.ValueString(), data.Port.ValueInt64(), data.Service.ValueString())
+
+	for _, part := range []types.String{data.Namespace, data.Partition} {
+		if !part.IsNull() {
+			id += "_" + part.ValueString()
+		}
+	}
+
+	return id
+}
+
+func (r *ConsulIngressGatewayServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulIngressGatewayServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.IngressGateway, data.Gateway.ValueString(), newIngressGateway(data.Gateway.ValueString()), ingressGatewayIsEmpty, data.addTo)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ingress gateway, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "ingress gateway service")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulIngressGatewayServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulIngressGatewayServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.IngressGatewayConfigEntry](client, api.IngressGateway, data.Gateway.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ingress gateway, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	i := findIngressListener(configEntry, int(data.Port.ValueInt64()))
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	listener := &configEntry.Listeners[i]
+	j := findIngressService(listener, data.ingressService())
+
+	if j == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Protocol = types.StringValue(listener.Protocol)
+
+	// An empty list of hosts is kept as configured
+	if hosts := listener.Services[j].Hosts; len(hosts) != 0 || len(data.Hosts) != 0 {
+		data.Hosts = make([]types.String, 0, len(hosts))
+
+		for _, host := range hosts {
+			data.Hosts = append(data.Hosts, types.StringValue(host))
+		}
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulIngressGatewayServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulIngressGatewayServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.IngressGateway, data.Gateway.ValueString(), newIngressGateway(data.Gateway.ValueString()), ingressGatewayIsEmpty, data.updateIn)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ingress gateway, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "ingress gateway service")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulIngressGatewayServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulIngressGatewayServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.IngressGateway, data.Gateway.ValueString(), newIngressGateway(data.Gateway.ValueString()), ingressGatewayIsEmpty, data.removeFrom)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write ingress gateway, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulIngressGatewayServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// A service with a partition but no namespace cannot be told apart from one with a namespace
+	attributes := []string{"gateway", "port", "service", "namespace", "partition"}
+
+	parts, err := parseImportID(req.ID, attributes[:3], attributes[:4], attributes)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	port, err := strconv.ParseInt(parts[1], 10, 64)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("invalid port %q in import ID %q", parts[1], req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), port)...)
+
+	for i, part := range parts {
+		if i != 1 {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attributes[i]), part)...)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
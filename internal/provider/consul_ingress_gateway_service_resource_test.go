@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulIngressGatewayServiceResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_ingress_gateway_service" "test" {
+	gateway  = "ingress"
+	port     = 8080
+	protocol = "http"
+	service  = "web"
+	hosts    = ["web.example.com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_ingress_gateway_service.test", "protocol", "http"),
+					resource.TestCheckResourceAttr("utils_consul_ingress_gateway_service.test", "id", "ingress_8080_web"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_ingress_gateway_service" "test" {
+	gateway  = "ingress"
+	port     = 8080
+	protocol = "http"
+	service  = "web"
+	hosts    = ["web.example.com", "www.example.com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_ingress_gateway_service.test", "hosts.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_ingress_gateway_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestGatewayServiceFragments(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	update := func(update func(configEntry *api.IngressGatewayConfigEntry) error) error {
+		return updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.IngressGateway, "ingress", newIngressGateway("ingress"), ingressGatewayIsEmpty, update)
+	}
+
+	web := ConsulIngressGatewayServiceResourceModel{Port: types.Int64Value(8080), Protocol: types.StringValue("http"), Service: types.StringValue("web")}
+	apiService := ConsulIngressGatewayServiceResourceModel{Port: types.Int64Value(8080), Protocol: types.StringValue("http"), Service: types.StringValue("api")}
+	db := ConsulIngressGatewayServiceResourceModel{Port: types.Int64Value(8080), Protocol: types.StringValue("tcp"), Service: types.StringValue("db")}
+
+	for _, data := range []ConsulIngressGatewayServiceResourceModel{web, apiService} {
+		if err := update(data.addTo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := update(db.addTo); err == nil {
+		t.Error("expected an error when attaching a service with another protocol than the listener")
+	}
+
+	// The service of another owner is only replaced on update
+	if err := update(web.addTo); err == nil {
+		t.Error("expected an error when attaching a service already on the listener")
+	}
+
+	if err := update(web.updateIn); err != nil {
+		t.Fatal(err)
+	}
+
+	configEntry, _, err := readTypedConfigEntry[*api.IngressGatewayConfigEntry](client, api.IngressGateway, "ingress")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(configEntry.Listeners) != 1 || len(configEntry.Listeners[0].Services) != 2 {
+		t.Fatalf("expected both services on the same listener, got %+v", configEntry.Listeners)
+	}
+
+	for _, data := range []ConsulIngressGatewayServiceResourceModel{web, apiService} {
+		if err := update(data.removeFrom); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if entry := store.entry(api.IngressGateway, "ingress"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last listener, got %+v", entry)
+	}
+}
+
+func TestConsulIngressGatewayServiceResourceImportState(t *testing.T) {
+	r := &ConsulIngressGatewayServiceResource{}
+
+	testImportState(t, r, "ingress_8080_web", map[string]attr.Value{
+		"gateway":   types.StringValue("ingress"),
+		"port":      types.Int64Value(8080),
+		"service":   types.StringValue("web"),
+		"namespace": types.StringNull(),
+		"id":        types.StringValue("ingress_8080_web"),
+	})
+
+	testImportState(t, r, "ingress_8080_web_team_eu", map[string]attr.Value{
+		"namespace": types.StringValue("team"),
+		"partition": types.StringValue("eu"),
+	})
+
+	for _, id := range []string{"ingress_web", "ingress_http_web", "ingress_8080_web_team_eu_other"} {
+		testImportState(t, r, id, nil)
+	}
+}
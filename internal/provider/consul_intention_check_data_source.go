@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConsulIntentionCheckDataSource{}
+
+func NewConsulIntentionCheckDataSource() datasource.DataSource {
+	return &ConsulIntentionCheckDataSource{}
+}
+
+// ConsulIntentionCheckDataSource defines the data source implementation.
+type ConsulIntentionCheckDataSource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulIntentionCheckDataSourceModel describes the data source data model.
+type ConsulIntentionCheckDataSourceModel struct {
+	Cluster            types.String `tfsdk:"cluster"`
+	SourceService      types.String `tfsdk:"source_service"`
+	SourcePeer         types.String `tfsdk:"source_peer"`
+	DestinationService types.String `tfsdk:"destination_service"`
+	Allowed            types.Bool   `tfsdk:"allowed"`
+}
+
+func (d *ConsulIntentionCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_intention_check"
+}
+
+func (d *ConsulIntentionCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks whether a source service is allowed to connect to a destination service, according to the intentions and the default ACL policy. " +
+			"Consul cannot check a source imported from a peer, so the provider matches it against the intentions of the destination service itself, and falls back to the default intention policy of the agent, which needs the `agent:read` permission, when none matches it.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+			},
+			"source_service": schema.StringAttribute{
+				MarkdownDescription: "The name of the source service",
+				Required:            true,
+			},
+			"source_peer": schema.StringAttribute{
+				MarkdownDescription: "The name of the peer the source service is imported from. Defaults to the local cluster",
+				Optional:            true,
+			},
+			"destination_service": schema.StringAttribute{
+				MarkdownDescription: "The name of the destination service",
+				Required:            true,
+			},
+			"allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the source service is allowed to connect to the destination service",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConsulIntentionCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ConsulIntentionCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConsulIntentionCheckDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	var allowed bool
+
+	if data.SourcePeer.ValueString() == "" {
+		allowed, _, err = client.Connect().IntentionCheck(&api.IntentionCheck{
+			Source:      data.SourceService.ValueString(),
+			Destination: data.DestinationService.ValueString(),
+			SourceType:  api.IntentionSourceConsul,
+		}, nil)
+	} else {
+		allowed, err = checkPeerIntention(client, data.SourceService.ValueString(), data.SourcePeer.ValueString(), data.DestinationService.ValueString())
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check intention, got error: %s", err))
+		return
+	}
+
+	data.Allowed = types.BoolValue(allowed)
+
+	tflog.Debug(ctx, "checked intention")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkPeerIntention tells whether the service imported from the peer is
+// allowed to connect to the destination service, which the intention check
+// of consul does not support.
+func checkPeerIntention(client *api.Client, sourceService, sourcePeer, destinationService string) (bool, error) {
+	configEntry, err := readServiceIntentions(client, destinationService)
+
+	if err != nil {
+		return false, err
+	}
+
+	if allowed, found := matchPeerIntention(configEntry, sourceService, sourcePeer); found {
+		return allowed, nil
+	}
+
+	return defaultIntentionAllowed(client)
+}
+
+// matchPeerIntention returns the action of the intention matching the service
+// imported from the peer, the intention naming the service taking precedence
+// over the wildcard one, or false when none matches it. As with the check of
+// consul, an intention with L7 permissions allows the connection.
+func matchPeerIntention(configEntry *api.ServiceIntentionsConfigEntry, sourceService, sourcePeer string) (bool, bool) {
+	for _, name := range []string{sourceService, "*"} {
+		if i := findSourceIntention(configEntry, name, sourcePeer); i != -1 {
+			return configEntry.Sources[i].Action != api.IntentionActionDeny, true
+		}
+	}
+
+	return false, false
+}
+
+// defaultIntentionAllowed tells whether the agent allows the connections no
+// intention matches: its default intention policy when set, or else the
+// default ACL policy, connections being allowed when ACLs are disabled.
+func defaultIntentionAllowed(client *api.Client) (bool, error) {
+	self, err := client.Agent().Self()
+
+	if err != nil {
+		return false, fmt.Errorf("unable to read the agent configuration: %w", err)
+	}
+
+	debugConfig := self["DebugConfig"]
+
+	if policy, _ := debugConfig["DefaultIntentionPolicy"].(string); policy != "" {
+		return policy == "allow", nil
+	}
+
+	if enabled, _ := debugConfig["ACLsEnabled"].(bool); !enabled {
+		return true, nil
+	}
+
+	resolverSettings, _ := debugConfig["ACLResolverSettings"].(map[string]interface{})
+	policy, _ := resolverSettings["ACLDefaultPolicy"].(string)
+
+	return policy != "deny", nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulIntentionCheckDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConsulIntentionCheckDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utils_consul_intention_check.test", "allowed", "true"),
+				),
+			},
+			// Read testing of denied sources, the default being to allow without ACLs
+			{
+				PreConfig: func() {
+					testAccWriteConfigEntries(t, &api.ServiceIntentionsConfigEntry{
+						Kind: api.ServiceIntentions,
+						Name: "invalid-service-check-deny",
+						Sources: []*api.SourceIntention{
+							{Name: "invalid-source-service", Action: api.IntentionActionDeny},
+							{Name: "invalid-source-service", Peer: "invalid-source-peer", Action: api.IntentionActionDeny},
+						},
+					})
+				},
+				Config: testAccConsulIntentionCheckDataSourceDenyConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utils_consul_intention_check.local", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.utils_consul_intention_check.peer", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.utils_consul_intention_check.other_peer", "allowed", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccConsulIntentionCheckDataSourceConfig = `
+resource "utils_consul_single_intention" "test" {
+	destination_service = "invalid-service-check"
+	source_service = "invalid-source-service"
+}
+
+data "utils_consul_intention_check" "test" {
+	source_service      = utils_consul_single_intention.test.source_service
+	destination_service = utils_consul_single_intention.test.destination_service
+}
+`
+
+const testAccConsulIntentionCheckDataSourceDenyConfig = `
+data "utils_consul_intention_check" "local" {
+	source_service      = "invalid-source-service"
+	destination_service = "invalid-service-check-deny"
+}
+
+data "utils_consul_intention_check" "peer" {
+	source_service      = "invalid-source-service"
+	source_peer         = "invalid-source-peer"
+	destination_service = "invalid-service-check-deny"
+}
+
+data "utils_consul_intention_check" "other_peer" {
+	source_service      = "invalid-source-service"
+	source_peer         = "other-source-peer"
+	destination_service = "invalid-service-check-deny"
+}
+`
+
+func TestMatchPeerIntention(t *testing.T) {
+	configEntry := &api.ServiceIntentionsConfigEntry{
+		Name: "web",
+		Sources: []*api.SourceIntention{
+			{Name: "*", Peer: "peer", Action: api.IntentionActionDeny},
+			{Name: "api", Peer: "peer", Action: api.IntentionActionAllow},
+			{Name: "admin", Peer: "peer", Permissions: []*api.IntentionPermission{{Action: api.IntentionActionAllow}}},
+			{Name: "api", Action: api.IntentionActionDeny},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		sourceService   string
+		sourcePeer      string
+		expectedAllowed bool
+		expectedFound   bool
+	}{
+		{name: "named", sourceService: "api", sourcePeer: "peer", expectedAllowed: true, expectedFound: true},
+		{name: "wildcard", sourceService: "db", sourcePeer: "peer", expectedAllowed: false, expectedFound: true},
+		{name: "permissions", sourceService: "admin", sourcePeer: "peer", expectedAllowed: true, expectedFound: true},
+		{name: "other peer", sourceService: "api", sourcePeer: "other", expectedAllowed: false, expectedFound: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, found := matchPeerIntention(configEntry, test.sourceService, test.sourcePeer)
+
+			if allowed != test.expectedAllowed || found != test.expectedFound {
+				t.Errorf("expected (%t, %t), got (%t, %t)", test.expectedAllowed, test.expectedFound, allowed, found)
+			}
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"fmt"
 
 	api "github.com/hashicorp/consul/api"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -29,15 +28,16 @@ func NewConsulKeyResource() resource.Resource {
 
 // ConsulKeyResource defines the resource implementation.
 type ConsulKeyResource struct {
-	client *api.Client
+	providerData *UtilsProviderData
 }
 
 // ConsulKeyResourceModel describes the resource data model.
 type ConsulKeyResourceModel struct {
-	Path   types.String `tfsdk:"path"`
-	Value  types.String `tfsdk:"value"`
-	Delete types.Bool   `tfsdk:"delete"`
-	Id     types.String `tfsdk:"id"`
+	Cluster types.String `tfsdk:"cluster"`
+	Path    types.String `tfsdk:"path"`
+	Value   types.String `tfsdk:"value"`
+	Delete  types.Bool   `tfsdk:"delete"`
+	Id      types.String `tfsdk:"id"`
 }
 
 func (r *ConsulKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +50,13 @@ func (r *ConsulKeyResource) Schema(ctx context.Context, req resource.SchemaReque
 		MarkdownDescription: "This resource allows you to manage keys in Consul KV store.",
 
 		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"path": schema.StringAttribute{
 				MarkdownDescription: "The path to the key in the Consul KV store",
 				Required:            true,
@@ -87,20 +94,18 @@ func (r *ConsulKeyResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	createClient := req.ProviderData.(func(diagnostics *diag.Diagnostics) (*api.Client, error))
-
-	client, err := createClient(&resp.Diagnostics)
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
 
-	if err != nil {
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
 }
 
 func (r *ConsulKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -113,7 +118,14 @@ func (r *ConsulKeyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	_, err := r.client.KV().Put(&api.KVPair{
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	_, err = client.KV().Put(&api.KVPair{
 		Key:   data.Path.ValueString(),
 		Value: []byte(data.Value.ValueString()),
 	}, nil)
@@ -140,7 +152,14 @@ func (r *ConsulKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	key, _, err := r.client.KV().Get(data.Path.ValueString(), nil)
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	key, _, err := client.KV().Get(data.Path.ValueString(), nil)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read key, got error: %s", err))
@@ -172,8 +191,15 @@ func (r *ConsulKeyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
 	if oldData.Delete.ValueBool() {
-		_, err := r.client.KV().Delete(data.Path.ValueString(), nil)
+		_, err := client.KV().Delete(data.Path.ValueString(), nil)
 
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete key, got error: %s", err))
@@ -181,7 +207,7 @@ func (r *ConsulKeyResource) Update(ctx context.Context, req resource.UpdateReque
 		}
 	}
 
-	_, err := r.client.KV().Put(&api.KVPair{
+	_, err = client.KV().Put(&api.KVPair{
 		Key:   data.Path.ValueString(),
 		Value: []byte(data.Value.ValueString()),
 	}, nil)
@@ -208,8 +234,15 @@ func (r *ConsulKeyResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
 	if data.Delete.ValueBool() {
-		_, err := r.client.KV().Delete(data.Path.ValueString(), nil)
+		_, err := client.KV().Delete(data.Path.ValueString(), nil)
 
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete key, got error: %s", err))
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultPeeringWaitTimeout = "5m"
+
+// Interval between two reads of a peering while waiting for it to become active
+const peeringPollInterval = 2 * time.Second
+
+// validateWaitTimeout checks the wait_for_active_timeout of config, so that an
+// invalid duration fails the plan rather than the apply.
+func validateWaitTimeout(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var waitTimeout types.String
+
+	diags.Append(config.GetAttribute(ctx, path.Root("wait_for_active_timeout"), &waitTimeout)...)
+
+	if diags.HasError() || waitTimeout.IsNull() || waitTimeout.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(waitTimeout.ValueString()); err != nil {
+		diags.AddAttributeError(path.Root("wait_for_active_timeout"), "Invalid Duration", fmt.Sprintf("Unable to parse wait_for_active_timeout %q: %s", waitTimeout.ValueString(), err))
+	}
+}
+
+// checkPeering ensures the peering named peerName exists and, when waitForActive
+// is set, polls it until its state is ACTIVE or the timeout expires.
+func checkPeering(ctx context.Context, client *api.Client, peerName string, validatePeer, waitForActive types.Bool, waitTimeout types.String) error {
+	if !validatePeer.ValueBool() && !waitForActive.ValueBool() {
+		return nil
+	}
+
+	peering, _, err := client.Peerings().Read(ctx, peerName, nil)
+
+	if err != nil {
+		return fmt.Errorf("unable to read peering %q: %w", peerName, err)
+	}
+
+	if peering == nil {
+		return fmt.Errorf("peering %q does not exist", peerName)
+	}
+
+	if !waitForActive.ValueBool() || peering.State == api.PeeringStateActive {
+		return nil
+	}
+
+	timeout, err := time.ParseDuration(waitTimeout.ValueString())
+
+	if err != nil {
+		return fmt.Errorf("invalid wait_for_active_timeout %q: %w", waitTimeout.ValueString(), err)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for peering.State != api.PeeringStateActive {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for peering %q to become %s, last state was %s", timeout, peerName, api.PeeringStateActive, peering.State)
+		}
+
+		tflog.Debug(ctx, "waiting for peering to become active", map[string]interface{}{
+			"peer":  peerName,
+			"state": string(peering.State),
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(peeringPollInterval):
+		}
+
+		peering, _, err = client.Peerings().Read(ctx, peerName, nil)
+
+		if err != nil {
+			return fmt.Errorf("unable to read peering %q: %w", peerName, err)
+		}
+
+		if peering == nil {
+			return fmt.Errorf("peering %q was deleted while waiting for it to become %s", peerName, api.PeeringStateActive)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConsulPeeringsDataSource{}
+
+func NewConsulPeeringsDataSource() datasource.DataSource {
+	return &ConsulPeeringsDataSource{}
+}
+
+// ConsulPeeringsDataSource defines the data source implementation.
+type ConsulPeeringsDataSource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulPeeringsDataSourceModel describes the data source data model.
+type ConsulPeeringsDataSourceModel struct {
+	Cluster   types.String                  `tfsdk:"cluster"`
+	Partition types.String                  `tfsdk:"partition"`
+	Peerings  []ConsulPeeringModel          `tfsdk:"peerings"`
+	Names     []types.String                `tfsdk:"names"`
+	ByName    map[string]ConsulPeeringModel `tfsdk:"by_name"`
+}
+
+// ConsulPeeringModel describes a single peering.
+type ConsulPeeringModel struct {
+	Id                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	State                types.String   `tfsdk:"state"`
+	Partition            types.String   `tfsdk:"partition"`
+	PeerId               types.String   `tfsdk:"peer_id"`
+	ImportedServiceCount types.Int64    `tfsdk:"imported_service_count"`
+	ImportedServices     []types.String `tfsdk:"imported_services"`
+	ExportedServiceCount types.Int64    `tfsdk:"exported_service_count"`
+	ExportedServices     []types.String `tfsdk:"exported_services"`
+}
+
+func consulPeeringAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The datacenter-scoped UUID of the peering",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The local name of the peering",
+			Computed:            true,
+		},
+		"state": schema.StringAttribute{
+			MarkdownDescription: "The state of the peering, such as `ACTIVE`, `PENDING` or `FAILING`",
+			Computed:            true,
+		},
+		"partition": schema.StringAttribute{
+			MarkdownDescription: "The local partition connecting to the peer",
+			Computed:            true,
+		},
+		"peer_id": schema.StringAttribute{
+			MarkdownDescription: "The ID the peer assigned to this peering",
+			Computed:            true,
+		},
+		"imported_service_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of services imported from the peer",
+			Computed:            true,
+		},
+		"imported_services": schema.ListAttribute{
+			MarkdownDescription: "The names of the services imported from the peer",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"exported_service_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of services exported to the peer",
+			Computed:            true,
+		},
+		"exported_services": schema.ListAttribute{
+			MarkdownDescription: "The names of the services exported to the peer",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func newConsulPeeringModel(peering *api.Peering) ConsulPeeringModel {
+	return ConsulPeeringModel{
+		Id:                   types.StringValue(peering.ID),
+		Name:                 types.StringValue(peering.Name),
+		State:                types.StringValue(string(peering.State)),
+		Partition:            types.StringValue(peering.Partition),
+		PeerId:               types.StringValue(peering.PeerID),
+		ImportedServiceCount: types.Int64Value(int64(len(peering.StreamStatus.ImportedServices))),
+		ImportedServices:     stringValues(peering.StreamStatus.ImportedServices),
+		ExportedServiceCount: types.Int64Value(int64(len(peering.StreamStatus.ExportedServices))),
+		ExportedServices:     stringValues(peering.StreamStatus.ExportedServices),
+	}
+}
+
+func stringValues(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+
+	return result
+}
+
+func (d *ConsulPeeringsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_peerings"
+}
+
+func (d *ConsulPeeringsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the cluster peerings along with the services imported from and exported to each peer.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+			},
+			"partition": schema.StringAttribute{
+				MarkdownDescription: "The partition to list the peerings of. Defaults to the partition of the token",
+				Optional:            true,
+			},
+			"peerings": schema.ListNestedAttribute{
+				MarkdownDescription: "The peerings, in the order returned by Consul",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: consulPeeringAttributes(),
+				},
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The names of the peerings",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"by_name": schema.MapNestedAttribute{
+				MarkdownDescription: "The peerings keyed by name, convenient for `for_each`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: consulPeeringAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *ConsulPeeringsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ConsulPeeringsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConsulPeeringsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	peerings, _, err := client.Peerings().List(ctx, &api.QueryOptions{
+		Partition: data.Partition.ValueString(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list peerings, got error: %s", err))
+		return
+	}
+
+	data.Peerings = make([]ConsulPeeringModel, 0, len(peerings))
+	data.Names = make([]types.String, 0, len(peerings))
+	data.ByName = make(map[string]ConsulPeeringModel, len(peerings))
+
+	for _, peering := range peerings {
+		peeringModel := newConsulPeeringModel(peering)
+
+		data.Peerings = append(data.Peerings, peeringModel)
+		data.Names = append(data.Names, peeringModel.Name)
+		data.ByName[peering.Name] = peeringModel
+	}
+
+	tflog.Debug(ctx, "read peerings")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
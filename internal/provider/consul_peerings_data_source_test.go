@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulPeeringsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConsulPeeringsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.utils_consul_peerings.test", "peerings.#"),
+					resource.TestCheckResourceAttrSet("data.utils_consul_peerings.test", "names.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccConsulPeeringsDataSourceConfig = `
+data "utils_consul_peerings" "test" {}
+`
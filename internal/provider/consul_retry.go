@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRequestTimeout  = "30s"
+	defaultMaxRetries      = 3
+	defaultRetryBackoffMin = "1s"
+	defaultRetryBackoffMax = "30s"
+)
+
+// RetryPolicy describes how requests to Consul, and CAS writes of config
+// entries, are retried.
+type RetryPolicy struct {
+	// Timeout of a single attempt, zero meaning no timeout
+	RequestTimeout time.Duration
+	MaxRetries     int
+	BackoffMin     time.Duration
+	BackoffMax     time.Duration
+}
+
+// Backoff returns the delay to wait before the given retry, starting at 1.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.BackoffMin
+
+	for i := 1; i < retry && backoff < p.BackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > p.BackoffMax {
+		return p.BackoffMax
+	}
+
+	return backoff
+}
+
+func newRetryPolicy(providerModel UtilsProviderModel) (RetryPolicy, error) {
+	var err error
+
+	policy := RetryPolicy{
+		MaxRetries: defaultMaxRetries,
+	}
+
+	durations := []struct {
+		attribute    string
+		value        string
+		defaultValue string
+		target       *time.Duration
+	}{
+		{"request_timeout", providerModel.RequestTimeout.ValueString(), defaultRequestTimeout, &policy.RequestTimeout},
+		{"retry_backoff_min", providerModel.RetryBackoffMin.ValueString(), defaultRetryBackoffMin, &policy.BackoffMin},
+		{"retry_backoff_max", providerModel.RetryBackoffMax.ValueString(), defaultRetryBackoffMax, &policy.BackoffMax},
+	}
+
+	for _, duration := range durations {
+		value := duration.value
+
+		if value == "" {
+			value = duration.defaultValue
+		}
+
+		*duration.target, err = time.ParseDuration(value)
+
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s %q: %w", duration.attribute, value, err)
+		}
+	}
+
+	if !providerModel.MaxRetries.IsNull() {
+		policy.MaxRetries = int(providerModel.MaxRetries.ValueInt64())
+	}
+
+	if policy.MaxRetries < 0 {
+		return policy, fmt.Errorf("invalid max_retries %d: must not be negative", policy.MaxRetries)
+	}
+
+	if policy.BackoffMin > policy.BackoffMax {
+		return policy, fmt.Errorf("retry_backoff_min %s is greater than retry_backoff_max %s", policy.BackoffMin, policy.BackoffMax)
+	}
+
+	return policy, nil
+}
+
+// sleepContext waits for d, returning early with an error when ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransientError tells whether err is a network error or a 5xx or 429
+// response, which a read-modify-write can safely try again.
+func isTransientError(err error) bool {
+	var statusError api.StatusError
+
+	if errors.As(err, &statusError) {
+		return statusError.Code >= 500 || statusError.Code == http.StatusTooManyRequests
+	}
+
+	var urlError *url.Error
+
+	return errors.As(err, &urlError)
+}
+
+// retryOnCASConflict runs a read-modify-write of a config entry until its CAS
+// write succeeds, rereading the entry each time another writer got there first.
+// Transient errors are retried the same way: a write whose response was lost
+// either failed, or makes the next CAS write fail and the entry be read again.
+func retryOnCASConflict(ctx context.Context, policy RetryPolicy, description string, readModifyWrite func() (bool, error)) error {
+	for retry := 0; ; retry++ {
+		written, err := readModifyWrite()
+
+		if written || (err != nil && (!isTransientError(err) || ctx.Err() != nil)) {
+			return err
+		}
+
+		if retry >= policy.MaxRetries {
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("%s was modified concurrently %d times in a row, giving up", description, retry+1)
+		}
+
+		backoff := policy.Backoff(retry + 1)
+
+		if err != nil {
+			tflog.Warn(ctx, "retrying read-modify-write after an error", map[string]interface{}{
+				"entry":   description,
+				"retry":   retry + 1,
+				"backoff": backoff.String(),
+				"error":   err.Error(),
+			})
+		} else {
+			tflog.Warn(ctx, "retrying CAS write after a conflict", map[string]interface{}{
+				"entry":   description,
+				"retry":   retry + 1,
+				"backoff": backoff.String(),
+			})
+		}
+
+		if err := sleepContext(ctx, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// retryTransport retries requests failing with a network error, a 5xx or a
+// 429 status. Only reads are retried on errors and 5xx, as most Consul writes
+// create a new object each time they are sent, while 429 responses are
+// retried for any method since the request was not processed. Config entry
+// writes are instead retried by their CAS read-modify-write loop.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	// Requests sent by the consul client are not bound to a context carrying
+	// the terraform logger, so retries are logged with the provider one.
+	logCtx context.Context
+}
+
+// newRetryingHTTPClient returns an HTTP client retrying requests according to policy.
+func newRetryingHTTPClient(ctx context.Context, policy RetryPolicy) *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			base:   http.DefaultTransport,
+			policy: policy,
+			logCtx: ctx,
+		},
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isSafeMethod(req.Method)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode >= 500 && isSafeMethod(req.Method)
+}
+
+// retryAfter returns the delay requested by the Retry-After header, if any.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retry := 0; ; retry++ {
+		attempt, err := t.rewind(req, retry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.roundTripWithTimeout(attempt)
+
+		if retry >= t.policy.MaxRetries || !shouldRetry(attempt, resp, err) {
+			return resp, err
+		}
+
+		// A body which cannot be sent again ends the retries
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		backoff := t.policy.Backoff(retry + 1)
+
+		if delay, ok := retryAfter(resp); ok && delay > backoff && delay <= t.policy.BackoffMax {
+			backoff = delay
+		}
+
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"retry":   retry + 1,
+			"backoff": backoff.String(),
+		}
+
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Warn(t.logCtx, "retrying consul request", fields)
+
+		if err := sleepContext(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewind returns the request to send for the given retry, with a fresh body.
+func (t *retryTransport) rewind(req *http.Request, retry int) (*http.Request, error) {
+	if retry == 0 || req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := req.Clone(req.Context())
+	attempt.Body = body
+
+	return attempt, nil
+}
+
+func (t *retryTransport) roundTripWithTimeout(req *http.Request) (*http.Response, error) {
+	if t.policy.RequestTimeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.policy.RequestTimeout)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The timeout also covers reading the body, so it is released on close
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+
+	return err
+}
@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testRetryPolicy = RetryPolicy{
+	RequestTimeout: time.Second,
+	MaxRetries:     2,
+	BackoffMin:     time.Millisecond,
+	BackoffMax:     4 * time.Millisecond,
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BackoffMin: time.Second, BackoffMax: 5 * time.Second}
+
+	for retry, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if backoff := policy.Backoff(retry + 1); backoff != expected {
+			t.Errorf("retry %d: expected a backoff of %s, got %s", retry+1, expected, backoff)
+		}
+	}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy, err := newRetryPolicy(UtilsProviderModel{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policy.RequestTimeout != 30*time.Second || policy.MaxRetries != defaultMaxRetries || policy.BackoffMin != time.Second || policy.BackoffMax != 30*time.Second {
+		t.Errorf("unexpected default policy %+v", policy)
+	}
+
+	invalid := []UtilsProviderModel{
+		{RequestTimeout: types.StringValue("soon")},
+		{MaxRetries: types.Int64Value(-1)},
+		{RetryBackoffMin: types.StringValue("1m"), RetryBackoffMax: types.StringValue("1s")},
+	}
+
+	for _, providerModel := range invalid {
+		if _, err := newRetryPolicy(providerModel); err == nil {
+			t.Errorf("expected an error for %+v", providerModel)
+		}
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		statuses []int
+		expected int
+		attempts int32
+	}{
+		{"read recovering from 5xx", http.MethodGet, []int{503, 500, 200}, 200, 3},
+		{"read giving up after max retries", http.MethodGet, []int{503, 503, 503, 503}, 503, 3},
+		{"write not retried on 5xx", http.MethodPut, []int{500, 200}, 500, 1},
+		{"delete not retried on 5xx", http.MethodDelete, []int{500, 200}, 500, 1},
+		{"write retried on 429", http.MethodPost, []int{429, 200}, 200, 2},
+		{"client error not retried", http.MethodGet, []int{404, 200}, 404, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+
+				if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+					t.Errorf("attempt %d: expected the body to be sent again, got %q", attempt, body)
+				}
+
+				w.WriteHeader(tc.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader("payload"))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := newRetryingHTTPClient(context.Background(), testRetryPolicy).Do(req)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.expected {
+				t.Errorf("expected status %d, got %d", tc.expected, resp.StatusCode)
+			}
+
+			if attempts.Load() != tc.attempts {
+				t.Errorf("expected %d attempts, got %d", tc.attempts, attempts.Load())
+			}
+		})
+	}
+}
+
+func TestRetryTransportRequestTimeout(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the first attempt hangs
+		if attempts.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy
+	policy.RequestTimeout = 50 * time.Millisecond
+
+	resp, err := newRetryingHTTPClient(context.Background(), policy).Get(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Errorf("expected the retried response, got %q", body)
+	}
+
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryOnCASConflict(t *testing.T) {
+	var modifyIndex atomic.Uint64
+	var writes atomic.Int32
+
+	modifyIndex.Store(7)
+
+	// A config entry store whose entry is modified concurrently with the first write
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprintf(w, `{"Kind": "exported-services", "Name": "default", "Services": [{"Name": "other"}], "ModifyIndex": %d}`, modifyIndex.Load())
+		case http.MethodPut:
+			written := r.URL.Query().Get("cas") == strconv.FormatUint(modifyIndex.Load(), 10)
+
+			if writes.Add(1) == 1 {
+				modifyIndex.Add(1)
+				written = false
+			}
+
+			_, _ = fmt.Fprint(w, written)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = updateExportedServices(context.Background(), client, testRetryPolicy, func(configEntry *api.ExportedServicesConfigEntry) {
+		addExportedService(configEntry, "web", "peer")
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if writes.Load() != 2 {
+		t.Errorf("expected 2 CAS writes, got %d", writes.Load())
+	}
+
+	policy := testRetryPolicy
+	policy.MaxRetries = 0
+	writes.Store(0)
+
+	err = updateExportedServices(context.Background(), client, policy, func(configEntry *api.ExportedServicesConfigEntry) {
+		addExportedService(configEntry, "web", "peer")
+	})
+
+	if err == nil {
+		t.Error("expected an error when the conflict persists after the retries")
+	}
+}
+
+func TestRetryOnCASConflictLostResponse(t *testing.T) {
+	var mutex sync.Mutex
+	var writes int
+
+	stored := []byte(`{"Kind": "exported-services", "Name": "default", "Services": [{"Name": "other"}], "ModifyIndex": 7}`)
+
+	// A config entry store failing to answer the first write it applied
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write(stored)
+		case http.MethodPut:
+			var configEntry api.ExportedServicesConfigEntry
+
+			body, _ := io.ReadAll(r.Body)
+
+			if err := json.Unmarshal(body, &configEntry); err != nil {
+				t.Error(err)
+			}
+
+			configEntry.ModifyIndex = uint64(8 + writes)
+			stored, _ = json.Marshal(configEntry)
+			writes++
+
+			if writes == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = fmt.Fprint(w, true)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = updateExportedServices(context.Background(), client, testRetryPolicy, func(configEntry *api.ExportedServicesConfigEntry) {
+		addExportedService(configEntry, "web", "peer")
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configEntry, err := readExportedServices(client)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(configEntry.Services) != 2 || len(configEntry.Services[1].Consumers) != 1 {
+		t.Errorf("expected web to be exported once, got %+v", configEntry.Services)
+	}
+
+	if writes != 2 {
+		t.Errorf("expected 2 CAS writes, got %d", writes)
+	}
+}
@@ -0,0 +1,453 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulServiceDefaultsFieldResource{}
+var _ resource.ResourceWithImportState = &ConsulServiceDefaultsFieldResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulServiceDefaultsFieldResource{}
+
+func newServiceDefaults(service string) func() *api.ServiceConfigEntry {
+	return func() *api.ServiceConfigEntry {
+		return &api.ServiceConfigEntry{
+			Kind: api.ServiceDefaults,
+			Name: service,
+		}
+	}
+}
+
+// serviceDefaultsIsEmpty tells whether no fragment of the service-defaults
+// entry is left. Consul returns the empty structures of the entry, such as
+// "TransparentProxy": {}, which are not fragments.
+func serviceDefaultsIsEmpty(configEntry *api.ServiceConfigEntry) bool {
+	remaining := *configEntry
+	remaining.CreateIndex = 0
+	remaining.ModifyIndex = 0
+
+	if remaining.TransparentProxy != nil && *remaining.TransparentProxy == (api.TransparentProxyConfig{}) {
+		remaining.TransparentProxy = nil
+	}
+
+	if !remaining.Expose.Checks && len(remaining.Expose.Paths) == 0 {
+		remaining.Expose = api.ExposeConfig{}
+	}
+
+	if remaining.UpstreamConfig != nil && len(remaining.UpstreamConfig.Overrides) == 0 && remaining.UpstreamConfig.Defaults == nil {
+		remaining.UpstreamConfig = nil
+	}
+
+	if len(remaining.EnvoyExtensions) == 0 {
+		remaining.EnvoyExtensions = nil
+	}
+
+	if len(remaining.Meta) == 0 {
+		remaining.Meta = nil
+	}
+
+	return reflect.DeepEqual(remaining, api.ServiceConfigEntry{
+		Kind:      configEntry.Kind,
+		Name:      configEntry.Name,
+		Partition: configEntry.Partition,
+		Namespace: configEntry.Namespace,
+	})
+}
+
+func NewConsulServiceDefaultsFieldResource() resource.Resource {
+	return &ConsulServiceDefaultsFieldResource{}
+}
+
+// ConsulServiceDefaultsFieldResource defines the resource implementation.
+type ConsulServiceDefaultsFieldResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceDefaultsFieldResourceModel describes the resource data model.
+type ConsulServiceDefaultsFieldResourceModel struct {
+	Cluster               types.String `tfsdk:"cluster"`
+	Service               types.String `tfsdk:"service"`
+	Protocol              types.String `tfsdk:"protocol"`
+	MaxInboundConnections types.Int64  `tfsdk:"max_inbound_connections"`
+	Id                    types.String `tfsdk:"id"`
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_defaults_field"
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single field of the `service-defaults` config entry of a service, so that several teams can each own a different field of the same entry. Exactly one of `protocol` and `max_inbound_connections` must be set, the upstream overrides being managed by `utils_consul_service_upstream_override`. The entry is created with the first field and deleted with the last one, and creating the resource fails when its field is already set.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The name of the service whose `service-defaults` entry is modified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "The protocol of the service, such as `tcp`, `http`, `http2` or `grpc`",
+				Optional:            true,
+			},
+			"max_inbound_connections": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of concurrent inbound connections to each instance of the service",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service defaults field identifier",
+			},
+		},
+	}
+}
+
+func (r *ConsulServiceDefaultsFieldResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var protocol types.String
+	var maxInboundConnections types.Int64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("protocol"), &protocol)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_inbound_connections"), &maxInboundConnections)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fields := []attr.Value{protocol, maxInboundConnections}
+	set := 0
+
+	for _, field := range fields {
+		// Variables are only known once they are applied
+		if field.IsUnknown() {
+			return
+		}
+
+		if !field.IsNull() {
+			set++
+		}
+	}
+
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Service Defaults Field",
+			"Exactly one of protocol and max_inbound_connections must be set.",
+		)
+	}
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// setField sets the field of data in the service-defaults entry.
+func (data ConsulServiceDefaultsFieldResourceModel) setField(configEntry *api.ServiceConfigEntry) {
+	switch {
+	case !data.Protocol.IsNull():
+		configEntry.Protocol = data.Protocol.ValueString()
+	case !data.MaxInboundConnections.IsNull():
+		configEntry.MaxInboundConnections = int(data.MaxInboundConnections.ValueInt64())
+	}
+}
+
+// hasField tells whether the field of data is already set in the service-defaults entry.
+func (data ConsulServiceDefaultsFieldResourceModel) hasField(configEntry *api.ServiceConfigEntry) bool {
+	switch {
+	case !data.Protocol.IsNull():
+		return configEntry.Protocol != ""
+	case !data.MaxInboundConnections.IsNull():
+		return configEntry.MaxInboundConnections != 0
+	}
+
+	return false
+}
+
+// clearField removes the field of data from the service-defaults entry.
+func (data ConsulServiceDefaultsFieldResourceModel) clearField(configEntry *api.ServiceConfigEntry) {
+	switch {
+	case !data.Protocol.IsNull():
+		configEntry.Protocol = ""
+	case !data.MaxInboundConnections.IsNull():
+		configEntry.MaxInboundConnections = 0
+	}
+}
+
+// readField updates data with the value of its field in the service-defaults
+// entry, returning false when the field is not set.
+func (data *ConsulServiceDefaultsFieldResourceModel) readField(configEntry *api.ServiceConfigEntry) bool {
+	switch {
+	case !data.Protocol.IsNull():
+		if configEntry.Protocol == "" {
+			return false
+		}
+
+		data.Protocol = types.StringValue(configEntry.Protocol)
+	case !data.MaxInboundConnections.IsNull():
+		if configEntry.MaxInboundConnections == 0 {
+			return false
+		}
+
+		data.MaxInboundConnections = types.Int64Value(int64(configEntry.MaxInboundConnections))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// fieldName names the field of data, as it appears in the identifier.
+func (data ConsulServiceDefaultsFieldResourceModel) fieldName() string {
+	switch {
+	case !data.Protocol.IsNull():
+		return "protocol"
+	case !data.MaxInboundConnections.IsNull():
+		return "max_inbound_connections"
+	}
+
+	return ""
+}
+
+func (data ConsulServiceDefaultsFieldResourceModel) id() string {
+	if field := data.fieldName(); field != "" {
+		return fmt.Sprintf("%s_%s", data.Service.ValueString(), field)
+	}
+
+	return data.Service.ValueString()
+}
+
+// optionalString returns a null string for the zero value of an optional field.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// optionalInt64 returns a null number for the zero value of an optional field.
+func optionalInt64(value int) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(value))
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulServiceDefaultsFieldResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceDefaults, data.Service.ValueString(), newServiceDefaults(data.Service.ValueString()), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+		// The field would be cleared on destroy, while it belongs to another owner
+		if data.hasField(configEntry) {
+			return fmt.Errorf("the field %s is already set", data.fieldName())
+		}
+
+		data.setField(configEntry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service defaults, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service defaults field")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulServiceDefaultsFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceConfigEntry](client, api.ServiceDefaults, data.Service.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service defaults, got error: %s", err))
+		return
+	}
+
+	if !found || !data.readField(configEntry) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulServiceDefaultsFieldResourceModel
+	var oldData ConsulServiceDefaultsFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	// The old field is replaced in the same write, so the entry is never left without either
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceDefaults, data.Service.ValueString(), newServiceDefaults(data.Service.ValueString()), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+		oldData.clearField(configEntry)
+
+		// Switching to another field takes it over only when it is not set
+		if data.fieldName() != oldData.fieldName() && data.hasField(configEntry) {
+			return fmt.Errorf("the field %s is already set", data.fieldName())
+		}
+
+		data.setField(configEntry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service defaults, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service defaults field")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceDefaultsFieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulServiceDefaultsFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceDefaults, data.Service.ValueString(), newServiceDefaults(data.Service.ValueString()), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+		data.clearField(configEntry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service defaults, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulServiceDefaultsFieldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Only the service is cut at the first underscore, as field names contain underscores
+	service, field, _ := strings.Cut(req.ID, "_")
+
+	data := ConsulServiceDefaultsFieldResourceModel{
+		Cluster:               types.StringNull(),
+		Service:               types.StringValue(service),
+		Protocol:              types.StringNull(),
+		MaxInboundConnections: types.Int64Null(),
+		Id:                    types.StringValue(req.ID),
+	}
+
+	// The field is given its zero value, replaced by the one read from consul
+	switch {
+	case service == "":
+	case field == "protocol":
+		data.Protocol = types.StringValue("")
+	case field == "max_inbound_connections":
+		data.MaxInboundConnections = types.Int64Value(0)
+	}
+
+	if data.Protocol.IsNull() && data.MaxInboundConnections.IsNull() {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("invalid import ID %q, expected <service>_protocol or <service>_max_inbound_connections", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
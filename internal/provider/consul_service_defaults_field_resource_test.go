@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceDefaultsFieldResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_service_defaults_field" "test" {
+	service  = "invalid-service"
+	protocol = "http"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_defaults_field.test", "protocol", "http"),
+					resource.TestCheckResourceAttr("utils_consul_service_defaults_field.test", "id", "invalid-service_protocol"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_service_defaults_field.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_service_defaults_field" "test" {
+	service                 = "invalid-service"
+	max_inbound_connections = 10
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_defaults_field.test", "max_inbound_connections", "10"),
+					resource.TestCheckResourceAttr("utils_consul_service_defaults_field.test", "id", "invalid-service_max_inbound_connections"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_service_defaults_field.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestAccConsulServiceDefaultsFieldResourceSeveralFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utils_consul_service_defaults_field" "test" {
+	service                 = "invalid-service"
+	protocol                = "http"
+	max_inbound_connections = 10
+}
+`,
+				ExpectError: regexp.MustCompile("Exactly one of protocol"),
+			},
+		},
+	})
+}
+
+func TestServiceDefaultsFields(t *testing.T) {
+	configEntry := newServiceDefaults("web")()
+
+	protocol := ConsulServiceDefaultsFieldResourceModel{Protocol: types.StringValue("http"), MaxInboundConnections: types.Int64Null()}
+	maxInboundConnections := ConsulServiceDefaultsFieldResourceModel{Protocol: types.StringNull(), MaxInboundConnections: types.Int64Value(10)}
+
+	protocol.setField(configEntry)
+
+	if !protocol.hasField(configEntry) || maxInboundConnections.hasField(configEntry) {
+		t.Error("expected only the protocol to be set")
+	}
+
+	setUpstreamOverride(configEntry, &api.UpstreamConfig{Name: "db"})
+	removeUpstreamOverride(configEntry, "db", "")
+	protocol.clearField(configEntry)
+
+	if !serviceDefaultsIsEmpty(configEntry) {
+		t.Error("expected an entry with an empty upstream configuration to be empty")
+	}
+
+	if configEntry.UpstreamConfig == nil {
+		t.Error("expected the entry not to be modified when checking whether it is empty")
+	}
+}
+
+func TestServiceDefaultsIsEmpty(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	// The entry as returned by consul, with the empty structures it adds
+	store.entries[api.ServiceDefaults+"/web"] = map[string]any{
+		"Kind":             api.ServiceDefaults,
+		"Name":             "web",
+		"Protocol":         "http",
+		"TransparentProxy": map[string]any{},
+		"MeshGateway":      map[string]any{},
+		"Expose":           map[string]any{},
+		"CreateIndex":      float64(1),
+		"ModifyIndex":      float64(1),
+	}
+	store.modifyIndex = 1
+
+	protocol := ConsulServiceDefaultsFieldResourceModel{Protocol: types.StringValue("http"), MaxInboundConnections: types.Int64Null()}
+
+	err := updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.ServiceDefaults, "web", newServiceDefaults("web"), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+		protocol.clearField(configEntry)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry := store.entry(api.ServiceDefaults, "web"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last field, got %+v", entry)
+	}
+
+	// The settings managed outside of the provider keep the entry
+	configEntry := newServiceDefaults("web")()
+	configEntry.Expose.Checks = true
+
+	if serviceDefaultsIsEmpty(configEntry) {
+		t.Error("expected an entry exposing its checks not to be empty")
+	}
+}
+
+func TestConsulServiceDefaultsFieldResourceImportState(t *testing.T) {
+	r := &ConsulServiceDefaultsFieldResource{}
+
+	testImportState(t, r, "web_protocol", map[string]attr.Value{
+		"service":                 types.StringValue("web"),
+		"protocol":                types.StringValue(""),
+		"max_inbound_connections": types.Int64Null(),
+		"id":                      types.StringValue("web_protocol"),
+	})
+
+	testImportState(t, r, "web_max_inbound_connections", map[string]attr.Value{
+		"service":                 types.StringValue("web"),
+		"protocol":                types.StringNull(),
+		"max_inbound_connections": types.Int64Value(0),
+	})
+
+	for _, id := range []string{"web", "_protocol", "web_timeout", "web_max_inbound_sessions", "web_max_inbound_connections_other", "web_upstream_api"} {
+		testImportState(t, r, id, nil)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConsulServiceIntentionsDataSource{}
+
+func NewConsulServiceIntentionsDataSource() datasource.DataSource {
+	return &ConsulServiceIntentionsDataSource{}
+}
+
+// ConsulServiceIntentionsDataSource defines the data source implementation.
+type ConsulServiceIntentionsDataSource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceIntentionsDataSourceModel describes the data source data model.
+type ConsulServiceIntentionsDataSourceModel struct {
+	Cluster            types.String                 `tfsdk:"cluster"`
+	DestinationService types.String                 `tfsdk:"destination_service"`
+	Sources            []ConsulSourceIntentionModel `tfsdk:"sources"`
+}
+
+// ConsulSourceIntentionModel describes a single source of a service-intentions entry.
+type ConsulSourceIntentionModel struct {
+	Name          types.String                     `tfsdk:"name"`
+	Peer          types.String                     `tfsdk:"peer"`
+	Partition     types.String                     `tfsdk:"partition"`
+	Namespace     types.String                     `tfsdk:"namespace"`
+	SamenessGroup types.String                     `tfsdk:"sameness_group"`
+	Action        types.String                     `tfsdk:"action"`
+	Precedence    types.Int64                      `tfsdk:"precedence"`
+	Type          types.String                     `tfsdk:"type"`
+	Description   types.String                     `tfsdk:"description"`
+	Permissions   []ConsulIntentionPermissionModel `tfsdk:"permissions"`
+}
+
+// ConsulIntentionPermissionModel describes an L7 permission of a source intention.
+type ConsulIntentionPermissionModel struct {
+	Action     types.String                 `tfsdk:"action"`
+	PathExact  types.String                 `tfsdk:"path_exact"`
+	PathPrefix types.String                 `tfsdk:"path_prefix"`
+	PathRegex  types.String                 `tfsdk:"path_regex"`
+	Methods    []types.String               `tfsdk:"methods"`
+	Headers    []ConsulIntentionHeaderModel `tfsdk:"headers"`
+}
+
+// ConsulIntentionHeaderModel describes a header match of an L7 permission.
+type ConsulIntentionHeaderModel struct {
+	Name    types.String `tfsdk:"name"`
+	Present types.Bool   `tfsdk:"present"`
+	Exact   types.String `tfsdk:"exact"`
+	Prefix  types.String `tfsdk:"prefix"`
+	Suffix  types.String `tfsdk:"suffix"`
+	Regex   types.String `tfsdk:"regex"`
+	Invert  types.Bool   `tfsdk:"invert"`
+}
+
+func newConsulIntentionPermissionModel(permission *api.IntentionPermission) ConsulIntentionPermissionModel {
+	permissionModel := ConsulIntentionPermissionModel{
+		Action:     types.StringValue(string(permission.Action)),
+		PathExact:  types.StringValue(""),
+		PathPrefix: types.StringValue(""),
+		PathRegex:  types.StringValue(""),
+		Methods:    []types.String{},
+		Headers:    []ConsulIntentionHeaderModel{},
+	}
+
+	if permission.HTTP == nil {
+		return permissionModel
+	}
+
+	permissionModel.PathExact = types.StringValue(permission.HTTP.PathExact)
+	permissionModel.PathPrefix = types.StringValue(permission.HTTP.PathPrefix)
+	permissionModel.PathRegex = types.StringValue(permission.HTTP.PathRegex)
+	permissionModel.Methods = stringValues(permission.HTTP.Methods)
+
+	for _, header := range permission.HTTP.Header {
+		permissionModel.Headers = append(permissionModel.Headers, ConsulIntentionHeaderModel{
+			Name:    types.StringValue(header.Name),
+			Present: types.BoolValue(header.Present),
+			Exact:   types.StringValue(header.Exact),
+			Prefix:  types.StringValue(header.Prefix),
+			Suffix:  types.StringValue(header.Suffix),
+			Regex:   types.StringValue(header.Regex),
+			Invert:  types.BoolValue(header.Invert),
+		})
+	}
+
+	return permissionModel
+}
+
+func (d *ConsulServiceIntentionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_intentions"
+}
+
+func (d *ConsulServiceIntentionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads the service-intentions config entry of a destination service.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+			},
+			"destination_service": schema.StringAttribute{
+				MarkdownDescription: "The name of the destination service",
+				Required:            true,
+			},
+			"sources": schema.ListNestedAttribute{
+				MarkdownDescription: "The sources allowed or denied to reach the destination service. Empty when the entry does not exist",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the source service",
+							Computed:            true,
+						},
+						"peer": schema.StringAttribute{
+							MarkdownDescription: "The name of the source peer",
+							Computed:            true,
+						},
+						"partition": schema.StringAttribute{
+							MarkdownDescription: "The partition of the source service",
+							Computed:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "The namespace of the source service",
+							Computed:            true,
+						},
+						"sameness_group": schema.StringAttribute{
+							MarkdownDescription: "The sameness group of the source service",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "The L4 action of the intention, `allow` or `deny`. Empty when `permissions` are used",
+							Computed:            true,
+						},
+						"precedence": schema.Int64Attribute{
+							MarkdownDescription: "The precedence of the intention",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the source",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the intention",
+							Computed:            true,
+						},
+						"permissions": schema.ListNestedAttribute{
+							MarkdownDescription: "The L7 permissions of the intention",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"action": schema.StringAttribute{
+										MarkdownDescription: "The action applied when the request matches, `allow` or `deny`",
+										Computed:            true,
+									},
+									"path_exact": schema.StringAttribute{
+										MarkdownDescription: "The exact path matched by the permission",
+										Computed:            true,
+									},
+									"path_prefix": schema.StringAttribute{
+										MarkdownDescription: "The path prefix matched by the permission",
+										Computed:            true,
+									},
+									"path_regex": schema.StringAttribute{
+										MarkdownDescription: "The path regular expression matched by the permission",
+										Computed:            true,
+									},
+									"methods": schema.ListAttribute{
+										MarkdownDescription: "The HTTP methods matched by the permission",
+										ElementType:         types.StringType,
+										Computed:            true,
+									},
+									"headers": schema.ListNestedAttribute{
+										MarkdownDescription: "The HTTP headers matched by the permission",
+										Computed:            true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"name": schema.StringAttribute{
+													MarkdownDescription: "The name of the header",
+													Computed:            true,
+												},
+												"present": schema.BoolAttribute{
+													MarkdownDescription: "Whether the header only has to be present",
+													Computed:            true,
+												},
+												"exact": schema.StringAttribute{
+													MarkdownDescription: "The exact value of the header",
+													Computed:            true,
+												},
+												"prefix": schema.StringAttribute{
+													MarkdownDescription: "The prefix of the header value",
+													Computed:            true,
+												},
+												"suffix": schema.StringAttribute{
+													MarkdownDescription: "The suffix of the header value",
+													Computed:            true,
+												},
+												"regex": schema.StringAttribute{
+													MarkdownDescription: "The regular expression matched by the header value",
+													Computed:            true,
+												},
+												"invert": schema.BoolAttribute{
+													MarkdownDescription: "Whether the match is inverted",
+													Computed:            true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ConsulServiceIntentionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ConsulServiceIntentionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConsulServiceIntentionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	// A service without intentions has no entry, read as one without sources
+	serviceIntentionsConfigEntry, err := readServiceIntentions(client, data.DestinationService.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service intentions, got error: %s", err))
+		return
+	}
+
+	data.Sources = make([]ConsulSourceIntentionModel, 0, len(serviceIntentionsConfigEntry.Sources))
+
+	for _, source := range serviceIntentionsConfigEntry.Sources {
+		sourceModel := ConsulSourceIntentionModel{
+			Name:          types.StringValue(source.Name),
+			Peer:          types.StringValue(source.Peer),
+			Partition:     types.StringValue(source.Partition),
+			Namespace:     types.StringValue(source.Namespace),
+			SamenessGroup: types.StringValue(source.SamenessGroup),
+			Action:        types.StringValue(string(source.Action)),
+			Precedence:    types.Int64Value(int64(source.Precedence)),
+			Type:          types.StringValue(string(source.Type)),
+			Description:   types.StringValue(source.Description),
+			Permissions:   make([]ConsulIntentionPermissionModel, 0, len(source.Permissions)),
+		}
+
+		for _, permission := range source.Permissions {
+			sourceModel.Permissions = append(sourceModel.Permissions, newConsulIntentionPermissionModel(permission))
+		}
+
+		data.Sources = append(data.Sources, sourceModel)
+	}
+
+	tflog.Debug(ctx, "read service intentions")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceIntentionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConsulServiceIntentionsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utils_consul_service_intentions.test", "sources.#", "1"),
+					resource.TestCheckResourceAttr("data.utils_consul_service_intentions.test", "sources.0.name", "invalid-source-service"),
+					resource.TestCheckResourceAttr("data.utils_consul_service_intentions.test", "sources.0.action", "allow"),
+				),
+			},
+		},
+	})
+}
+
+const testAccConsulServiceIntentionsDataSourceConfig = `
+resource "utils_consul_single_intention" "test" {
+	destination_service = "invalid-service-datasource"
+	source_service = "invalid-source-service"
+}
+
+data "utils_consul_service_intentions" "test" {
+	destination_service = utils_consul_single_intention.test.destination_service
+}
+`
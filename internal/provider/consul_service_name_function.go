@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// maxConsulServiceNameLength is the length of a DNS label, so that the
+// service can be resolved through the DNS interface of consul.
+const maxConsulServiceNameLength = 63
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ConsulServiceNameFunction{}
+
+func NewConsulServiceNameFunction() function.Function {
+	return &ConsulServiceNameFunction{}
+}
+
+// ConsulServiceNameFunction defines the function implementation.
+type ConsulServiceNameFunction struct{}
+
+func (f *ConsulServiceNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "consul_service_name"
+}
+
+func (f *ConsulServiceNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalizes a name to a DNS-safe consul service name",
+		MarkdownDescription: "Lowercases the name, replaces the characters other than letters, digits and dashes by dashes, collapses the consecutive dashes, trims the leading and the trailing dashes, and truncates the result to 63 characters, so that the service can be resolved through the DNS interface of consul. For example `My_App.v2` becomes `my-app-v2`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The name to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// consulServiceName returns the DNS-safe consul service name of name.
+func consulServiceName(name string) (string, error) {
+	var builder strings.Builder
+
+	dash := false
+
+	for _, c := range strings.ToLower(name) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			if dash && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+
+			builder.WriteRune(c)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	serviceName := builder.String()
+
+	if len(serviceName) > maxConsulServiceNameLength {
+		serviceName = strings.TrimRight(serviceName[:maxConsulServiceNameLength], "-")
+	}
+
+	if serviceName == "" {
+		return "", fmt.Errorf("the name %q has no letter nor digit to build a service name from", name)
+	}
+
+	return serviceName, nil
+}
+
+func (f *ConsulServiceNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+
+	if resp.Error != nil {
+		return
+	}
+
+	serviceName, err := consulServiceName(name)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, serviceName))
+}
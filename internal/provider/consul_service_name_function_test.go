@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccConsulServiceNameFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::utils::consul_service_name("My_App.v2")
+}
+`,
+				Check: resource.TestCheckOutput("test", "my-app-v2"),
+			},
+		},
+	})
+}
+
+func TestConsulServiceNameFunction(t *testing.T) {
+	testCases := map[string]struct {
+		name        string
+		expected    string
+		expectError bool
+	}{
+		"valid":           {name: "web", expected: "web"},
+		"uppercase":       {name: "Web-API", expected: "web-api"},
+		"separators":      {name: "My_App.v2", expected: "my-app-v2"},
+		"repeated":        {name: "a__b--c", expected: "a-b-c"},
+		"trimmed":         {name: "--web--", expected: "web"},
+		"non ascii":       {name: "café", expected: "caf"},
+		"truncated":       {name: strings.Repeat("a", 62) + "-b", expected: strings.Repeat("a", 62)},
+		"no valid":        {name: "__", expectError: true},
+		"empty":           {name: "", expectError: true},
+		"maximum length":  {name: strings.Repeat("a", 63), expected: strings.Repeat("a", 63)},
+		"digits and dots": {name: "10.0.0.1", expected: "10-0-0-1"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testRunFunction(t, NewConsulServiceNameFunction(), types.StringValue(testCase.name))
+
+			if testCase.expectError {
+				if funcErr == nil {
+					t.Errorf("expected an error, got %s", result)
+				}
+
+				return
+			}
+
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+
+			if !result.Equal(types.StringValue(testCase.expected)) {
+				t.Errorf("expected %q, got %s", testCase.expected, result)
+			}
+		})
+	}
+}
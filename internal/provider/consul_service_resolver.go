@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+
+	api "github.com/hashicorp/consul/api"
+)
+
+// Key of the failover applying to every subset of a service-resolver entry
+const defaultFailoverSubset = "*"
+
+func newServiceResolver(resolver string) func() *api.ServiceResolverConfigEntry {
+	return func() *api.ServiceResolverConfigEntry {
+		return &api.ServiceResolverConfigEntry{
+			Kind: api.ServiceResolver,
+			Name: resolver,
+		}
+	}
+}
+
+// serviceResolverIsEmpty tells whether no fragment of the service-resolver
+// entry is left. Consul returns the empty structures of the entry, such as
+// "PrioritizeByLocality": {}, which are not fragments.
+func serviceResolverIsEmpty(configEntry *api.ServiceResolverConfigEntry) bool {
+	remaining := *configEntry
+	remaining.CreateIndex = 0
+	remaining.ModifyIndex = 0
+
+	if remaining.Redirect != nil && *remaining.Redirect == (api.ServiceResolverRedirect{}) {
+		remaining.Redirect = nil
+	}
+
+	if remaining.PrioritizeByLocality != nil && *remaining.PrioritizeByLocality == (api.ServiceResolverPrioritizeByLocality{}) {
+		remaining.PrioritizeByLocality = nil
+	}
+
+	if loadBalancer := remaining.LoadBalancer; loadBalancer != nil && loadBalancer.Policy == "" && loadBalancer.RingHashConfig == nil && loadBalancer.LeastRequestConfig == nil && len(loadBalancer.HashPolicies) == 0 {
+		remaining.LoadBalancer = nil
+	}
+
+	if len(remaining.Subsets) == 0 {
+		remaining.Subsets = nil
+	}
+
+	if len(remaining.Failover) == 0 {
+		remaining.Failover = nil
+	}
+
+	if len(remaining.Meta) == 0 {
+		remaining.Meta = nil
+	}
+
+	return reflect.DeepEqual(remaining, api.ServiceResolverConfigEntry{
+		Kind:      configEntry.Kind,
+		Name:      configEntry.Name,
+		Partition: configEntry.Partition,
+		Namespace: configEntry.Namespace,
+	})
+}
+
+// findFailoverTarget returns the index of target in the failover of subset, or -1 when there is none.
+func findFailoverTarget(configEntry *api.ServiceResolverConfigEntry, subset string, target api.ServiceResolverFailoverTarget) int {
+	for i, other := range configEntry.Failover[subset].Targets {
+		if other == target {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// addFailoverTarget adds target to the failover of subset, after the targets of the other teams.
+func addFailoverTarget(configEntry *api.ServiceResolverConfigEntry, subset string, target api.ServiceResolverFailoverTarget) {
+	if findFailoverTarget(configEntry, subset, target) != -1 {
+		return
+	}
+
+	if configEntry.Failover == nil {
+		configEntry.Failover = make(map[string]api.ServiceResolverFailover)
+	}
+
+	failover := configEntry.Failover[subset]
+	failover.Targets = append(failover.Targets, target)
+	configEntry.Failover[subset] = failover
+}
+
+// removeFailoverTarget removes target from the failover of subset, and the
+// failover itself once it is empty.
+func removeFailoverTarget(configEntry *api.ServiceResolverConfigEntry, subset string, target api.ServiceResolverFailoverTarget) {
+	i := findFailoverTarget(configEntry, subset, target)
+
+	if i == -1 {
+		return
+	}
+
+	failover := configEntry.Failover[subset]
+	failover.Targets = append(failover.Targets[:i], failover.Targets[i+1:]...)
+	setFailover(configEntry, subset, failover)
+}
+
+// setFailover sets the failover of subset, removing it when it is empty.
+func setFailover(configEntry *api.ServiceResolverConfigEntry, subset string, failover api.ServiceResolverFailover) {
+	if len(failover.Targets) == 0 {
+		failover.Targets = nil
+	}
+
+	if reflect.DeepEqual(failover, api.ServiceResolverFailover{}) {
+		delete(configEntry.Failover, subset)
+		return
+	}
+
+	if configEntry.Failover == nil {
+		configEntry.Failover = make(map[string]api.ServiceResolverFailover)
+	}
+
+	configEntry.Failover[subset] = failover
+}
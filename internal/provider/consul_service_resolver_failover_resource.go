@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulServiceResolverFailoverResource{}
+var _ resource.ResourceWithValidateConfig = &ConsulServiceResolverFailoverResource{}
+
+func NewConsulServiceResolverFailoverResource() resource.Resource {
+	return &ConsulServiceResolverFailoverResource{}
+}
+
+// ConsulServiceResolverFailoverResource defines the resource implementation.
+type ConsulServiceResolverFailoverResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceResolverFailoverResourceModel describes the resource data model.
+type ConsulServiceResolverFailoverResourceModel struct {
+	Cluster        types.String `tfsdk:"cluster"`
+	Resolver       types.String `tfsdk:"resolver"`
+	FailoverSubset types.String `tfsdk:"failover_subset"`
+	Service        types.String `tfsdk:"service"`
+	ServiceSubset  types.String `tfsdk:"service_subset"`
+	Namespace      types.String `tfsdk:"namespace"`
+	Partition      types.String `tfsdk:"partition"`
+	Datacenter     types.String `tfsdk:"datacenter"`
+	Peer           types.String `tfsdk:"peer"`
+	SamenessGroup  types.String `tfsdk:"sameness_group"`
+	Id             types.String `tfsdk:"id"`
+}
+
+func (r *ConsulServiceResolverFailoverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_resolver_failover"
+}
+
+func (r *ConsulServiceResolverFailoverResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Adds a single failover target to the `service-resolver` config entry of a service, so that each team can contribute its own target, such as the peer or datacenter of its region. " +
+			"Either `sameness_group` or at least one of the target attributes must be set. The targets are tried in the order they were added in, and creating the resource fails when its target is already in the failover.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resolver": schema.StringAttribute{
+				MarkdownDescription: "The name of the service whose `service-resolver` entry is modified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"failover_subset": schema.StringAttribute{
+				MarkdownDescription: "The subset of the service the failover applies to. Defaults to `*`, every subset",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultFailoverSubset),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The service of the target. Defaults to the resolver service",
+				Optional:            true,
+			},
+			"service_subset": schema.StringAttribute{
+				MarkdownDescription: "The subset of the service of the target",
+				Optional:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The namespace of the target",
+				Optional:            true,
+			},
+			"partition": schema.StringAttribute{
+				MarkdownDescription: "The partition of the target",
+				Optional:            true,
+			},
+			"datacenter": schema.StringAttribute{
+				MarkdownDescription: "The datacenter of the target",
+				Optional:            true,
+			},
+			"peer": schema.StringAttribute{
+				MarkdownDescription: "The peer of the target",
+				Optional:            true,
+			},
+			"sameness_group": schema.StringAttribute{
+				MarkdownDescription: "The sameness group whose members are the failover targets, instead of a single target. A failover has at most one sameness group",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service resolver failover identifier",
+			},
+		},
+	}
+}
+
+func (r *ConsulServiceResolverFailoverResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConsulServiceResolverFailoverResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targetAttributes := []types.String{data.Service, data.ServiceSubset, data.Namespace, data.Partition, data.Datacenter, data.Peer}
+	hasTarget := false
+
+	for _, attribute := range targetAttributes {
+		// Variables are only known once they are applied
+		if attribute.IsUnknown() {
+			return
+		}
+
+		hasTarget = hasTarget || !attribute.IsNull()
+	}
+
+	if data.SamenessGroup.IsUnknown() {
+		return
+	}
+
+	if hasTarget == !data.SamenessGroup.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Failover",
+			"Either sameness_group or at least one of service, service_subset, namespace, partition, datacenter and peer must be set.",
+		)
+	}
+}
+
+func (r *ConsulServiceResolverFailoverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (data ConsulServiceResolverFailoverResourceModel) target() api.ServiceResolverFailoverTarget {
+	return api.ServiceResolverFailoverTarget{
+		Service:       data.Service.ValueString(),
+		ServiceSubset: data.ServiceSubset.ValueString(),
+		Namespace:     data.Namespace.ValueString(),
+		Partition:     data.Partition.ValueString(),
+		Datacenter:    data.Datacenter.ValueString(),
+		Peer:          data.Peer.ValueString(),
+	}
+}
+
+// addTo adds the target, or the sameness group, of data to the failover.
+func (data ConsulServiceResolverFailoverResourceModel) addTo(configEntry *api.ServiceResolverConfigEntry) error {
+	subset := data.FailoverSubset.ValueString()
+
+	if data.SamenessGroup.IsNull() {
+		addFailoverTarget(configEntry, subset, data.target())
+		return nil
+	}
+
+	failover := configEntry.Failover[subset]
+
+	if failover.SamenessGroup != "" && failover.SamenessGroup != data.SamenessGroup.ValueString() {
+		return fmt.Errorf("the failover of subset %q already uses the sameness group %q", subset, failover.SamenessGroup)
+	}
+
+	failover.SamenessGroup = data.SamenessGroup.ValueString()
+	setFailover(configEntry, subset, failover)
+
+	return nil
+}
+
+// addNewTo adds the target, or the sameness group, of data to the failover,
+// refusing the one already there, as it belongs to another owner.
+func (data ConsulServiceResolverFailoverResourceModel) addNewTo(configEntry *api.ServiceResolverConfigEntry) error {
+	if data.isIn(configEntry) {
+		return fmt.Errorf("the failover of subset %q already has the target", data.FailoverSubset.ValueString())
+	}
+
+	return data.addTo(configEntry)
+}
+
+// removeFrom removes the target, or the sameness group, of data from the failover.
+func (data ConsulServiceResolverFailoverResourceModel) removeFrom(configEntry *api.ServiceResolverConfigEntry) {
+	subset := data.FailoverSubset.ValueString()
+
+	if data.SamenessGroup.IsNull() {
+		removeFailoverTarget(configEntry, subset, data.target())
+		return
+	}
+
+	failover, ok := configEntry.Failover[subset]
+
+	if ok && failover.SamenessGroup == data.SamenessGroup.ValueString() {
+		failover.SamenessGroup = ""
+		setFailover(configEntry, subset, failover)
+	}
+}
+
+// isIn tells whether the target, or the sameness group, of data is in the failover.
+func (data ConsulServiceResolverFailoverResourceModel) isIn(configEntry *api.ServiceResolverConfigEntry) bool {
+	subset := data.FailoverSubset.ValueString()
+
+	if data.SamenessGroup.IsNull() {
+		return findFailoverTarget(configEntry, subset, data.target()) != -1
+	}
+
+	return configEntry.Failover[subset].SamenessGroup == data.SamenessGroup.ValueString()
+}
+
+func (data ConsulServiceResolverFailoverResourceModel) id() string {
+	parts := []string{data.Resolver.ValueString(), data.FailoverSubset.ValueString()}
+
+	if !data.SamenessGroup.IsNull() {
+		return strings.Join(append(parts, data.SamenessGroup.ValueString()), "_")
+	}
+
+	for _, attribute := range []types.String{data.Service, data.ServiceSubset, data.Namespace, data.Partition, data.Datacenter, data.Peer} {
+		if !attribute.IsNull() {
+			parts = append(parts, attribute.ValueString())
+		}
+	}
+
+	return strings.Join(parts, "_")
+}
+
+func (r *ConsulServiceResolverFailoverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulServiceResolverFailoverResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceResolver, data.Resolver.ValueString(), newServiceResolver(data.Resolver.ValueString()), serviceResolverIsEmpty, data.addNewTo)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service resolver, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service resolver failover")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceResolverFailoverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulServiceResolverFailoverResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceResolverConfigEntry](client, api.ServiceResolver, data.Resolver.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service resolver, got error: %s", err))
+		return
+	}
+
+	if !found || !data.isIn(configEntry) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceResolverFailoverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulServiceResolverFailoverResourceModel
+	var oldData ConsulServiceResolverFailoverResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	// The old target is replaced in the same write, so the failover never lacks both
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceResolver, data.Resolver.ValueString(), newServiceResolver(data.Resolver.ValueString()), serviceResolverIsEmpty, func(configEntry *api.ServiceResolverConfigEntry) error {
+		oldData.removeFrom(configEntry)
+		return data.addNewTo(configEntry)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service resolver, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service resolver failover")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceResolverFailoverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulServiceResolverFailoverResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceResolver, data.Resolver.ValueString(), newServiceResolver(data.Resolver.ValueString()), serviceResolverIsEmpty, func(configEntry *api.ServiceResolverConfigEntry) error {
+		data.removeFrom(configEntry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service resolver, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceResolverFailoverResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_service_resolver_failover" "test" {
+	resolver   = "invalid-service"
+	datacenter = "invalid-dc-one"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_resolver_failover.test", "failover_subset", "*"),
+					resource.TestCheckResourceAttr("utils_consul_service_resolver_failover.test", "id", "invalid-service_*_invalid-dc-one"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_service_resolver_failover" "test" {
+	resolver   = "invalid-service"
+	datacenter = "invalid-dc-two"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_resolver_failover.test", "datacenter", "invalid-dc-two"),
+				),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestServiceResolverFragments(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	update := func(update func(configEntry *api.ServiceResolverConfigEntry) error) {
+		t.Helper()
+
+		err := updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.ServiceResolver, "web", newServiceResolver("web"), serviceResolverIsEmpty, update)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	east := ConsulServiceResolverFailoverResourceModel{FailoverSubset: types.StringValue("*"), Peer: types.StringValue("east")}
+	west := ConsulServiceResolverFailoverResourceModel{FailoverSubset: types.StringValue("*"), Peer: types.StringValue("west")}
+	subset := ConsulServiceResolverSubsetResourceModel{Name: types.StringValue("v2"), Filter: types.StringValue("Service.Meta.version == v2")}
+
+	update(east.addNewTo)
+	update(west.addNewTo)
+	update(subset.addSubset)
+
+	configEntry, _, err := readTypedConfigEntry[*api.ServiceResolverConfigEntry](client, api.ServiceResolver, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !east.isIn(configEntry) || !west.isIn(configEntry) || configEntry.Failover["*"].Targets[0].Peer != "east" {
+		t.Fatalf("expected both targets in the order they were added in, got %+v", configEntry.Failover)
+	}
+
+	// The fragments of another owner are refused rather than taken over
+	if east.addNewTo(configEntry) == nil || subset.addSubset(configEntry) == nil {
+		t.Error("expected the existing target and subset to be refused")
+	}
+
+	update(func(configEntry *api.ServiceResolverConfigEntry) error {
+		east.removeFrom(configEntry)
+		west.removeFrom(configEntry)
+		return nil
+	})
+
+	configEntry, _, err = readTypedConfigEntry[*api.ServiceResolverConfigEntry](client, api.ServiceResolver, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(configEntry.Failover) != 0 || configEntry.Subsets["v2"].Filter == "" {
+		t.Fatalf("expected only the failover to be removed, got %+v", configEntry)
+	}
+
+	update(func(configEntry *api.ServiceResolverConfigEntry) error {
+		delete(configEntry.Subsets, "v2")
+		return nil
+	})
+
+	if entry := store.entry(api.ServiceResolver, "web"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last fragment, got %+v", entry)
+	}
+}
+
+func TestServiceResolverIsEmpty(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	// The entry as returned by consul, with the empty structures it adds
+	store.entries[api.ServiceResolver+"/web"] = map[string]any{
+		"Kind":                 api.ServiceResolver,
+		"Name":                 "web",
+		"Subsets":              map[string]any{"v2": map[string]any{"Filter": "Service.Meta.version == v2"}},
+		"ConnectTimeout":       "0s",
+		"RequestTimeout":       "0s",
+		"PrioritizeByLocality": map[string]any{},
+		"LoadBalancer":         map[string]any{},
+		"CreateIndex":          float64(1),
+		"ModifyIndex":          float64(1),
+	}
+	store.modifyIndex = 1
+
+	subset := ConsulServiceResolverSubsetResourceModel{Name: types.StringValue("v2")}
+
+	err := updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.ServiceResolver, "web", newServiceResolver("web"), serviceResolverIsEmpty, func(configEntry *api.ServiceResolverConfigEntry) error {
+		delete(configEntry.Subsets, subset.Name.ValueString())
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry := store.entry(api.ServiceResolver, "web"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last subset, got %+v", entry)
+	}
+
+	// The settings managed outside of the provider keep the entry
+	configEntry := newServiceResolver("web")()
+	configEntry.LoadBalancer = &api.LoadBalancer{Policy: "least_request"}
+
+	if serviceResolverIsEmpty(configEntry) {
+		t.Error("expected an entry with a load balancer not to be empty")
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulServiceResolverSubsetResource{}
+var _ resource.ResourceWithImportState = &ConsulServiceResolverSubsetResource{}
+
+func NewConsulServiceResolverSubsetResource() resource.Resource {
+	return &ConsulServiceResolverSubsetResource{}
+}
+
+// ConsulServiceResolverSubsetResource defines the resource implementation.
+type ConsulServiceResolverSubsetResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceResolverSubsetResourceModel describes the resource data model.
+type ConsulServiceResolverSubsetResourceModel struct {
+	Cluster     types.String `tfsdk:"cluster"`
+	Resolver    types.String `tfsdk:"resolver"`
+	Name        types.String `tfsdk:"name"`
+	Filter      types.String `tfsdk:"filter"`
+	OnlyPassing types.Bool   `tfsdk:"only_passing"`
+	Id          types.String `tfsdk:"id"`
+}
+
+func (r *ConsulServiceResolverSubsetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_resolver_subset"
+}
+
+func (r *ConsulServiceResolverSubsetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single named subset of the `service-resolver` config entry of a service, so that several teams can each define their subsets in the same entry. Creating the resource fails when the subset already exists.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resolver": schema.StringAttribute{
+				MarkdownDescription: "The name of the service whose `service-resolver` entry is modified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the subset",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "The filter expression selecting the instances of the subset, such as `Service.Meta.version == v2`",
+				Optional:            true,
+			},
+			"only_passing": schema.BoolAttribute{
+				MarkdownDescription: "Whether the instances with a warning health check are excluded from the subset",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service resolver subset identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulServiceResolverSubsetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// setSubset sets the subset of data in the service-resolver entry.
+func (data ConsulServiceResolverSubsetResourceModel) setSubset(configEntry *api.ServiceResolverConfigEntry) error {
+	if configEntry.Subsets == nil {
+		configEntry.Subsets = make(map[string]api.ServiceResolverSubset)
+	}
+
+	configEntry.Subsets[data.Name.ValueString()] = api.ServiceResolverSubset{
+		Filter:      data.Filter.ValueString(),
+		OnlyPassing: data.OnlyPassing.ValueBool(),
+	}
+
+	return nil
+}
+
+// addSubset adds the subset of data to the service-resolver entry, refusing
+// the one already defined, as it belongs to another owner.
+func (data ConsulServiceResolverSubsetResourceModel) addSubset(configEntry *api.ServiceResolverConfigEntry) error {
+	if _, ok := configEntry.Subsets[data.Name.ValueString()]; ok {
+		return fmt.Errorf("the subset %q already exists", data.Name.ValueString())
+	}
+
+	return data.setSubset(configEntry)
+}
+
+func (data ConsulServiceResolverSubsetResourceModel) id() string {
+	return fmt.Sprintf("%s_%s", data.Resolver.ValueString(), data.Name.ValueString())
+}
+
+func (r *ConsulServiceResolverSubsetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulServiceResolverSubsetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceResolver, data.Resolver.ValueString(), newServiceResolver(data.Resolver.ValueString()), serviceResolverIsEmpty, data.addSubset)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service resolver, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service resolver subset")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceResolverSubsetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulServiceResolverSubsetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceResolverConfigEntry](client, api.ServiceResolver, data.Resolver.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service resolver, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	subset, ok := configEntry.Subsets[data.Name.ValueString()]
+
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Filter = optionalString(subset.Filter)
+	data.OnlyPassing = optionalBool(data.OnlyPassing, subset.OnlyPassing)
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceResolverSubsetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulServiceResolverSubsetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceResolver, data.Resolver.ValueString(), newServiceResolver(data.Resolver.ValueString()), serviceResolverIsEmpty, data.setSubset)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service resolver, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service resolver subset")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceResolverSubsetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulServiceResolverSubsetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceResolver, data.Resolver.ValueString(), newServiceResolver(data.Resolver.ValueString()), serviceResolverIsEmpty, func(configEntry *api.ServiceResolverConfigEntry) error {
+		delete(configEntry.Subsets, data.Name.ValueString())
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service resolver, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulServiceResolverSubsetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromID(ctx, req, resp, []string{"resolver", "name"})
+}
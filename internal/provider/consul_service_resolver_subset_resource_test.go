@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceResolverSubsetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_service_resolver_subset" "test" {
+	resolver = "invalid-service"
+	name     = "v1"
+	filter   = "Service.Meta.version == v1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_resolver_subset.test", "filter", "Service.Meta.version == v1"),
+					resource.TestCheckResourceAttr("utils_consul_service_resolver_subset.test", "id", "invalid-service_v1"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_service_resolver_subset" "test" {
+	resolver     = "invalid-service"
+	name         = "v1"
+	filter       = "Service.Meta.version == v1"
+	only_passing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_resolver_subset.test", "only_passing", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_service_resolver_subset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestConsulServiceResolverSubsetResourceImportState(t *testing.T) {
+	r := &ConsulServiceResolverSubsetResource{}
+
+	testImportState(t, r, "web_v2", map[string]attr.Value{
+		"resolver": types.StringValue("web"),
+		"name":     types.StringValue("v2"),
+		"id":       types.StringValue("web_v2"),
+	})
+
+	testImportState(t, r, "web_v2_other", nil)
+}
@@ -0,0 +1,659 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulServiceRouterRouteResource{}
+
+// Prefix of the meta keys of the service-router entries recording the
+// priority of the routes managed by this provider.
+const routePriorityMetaPrefix = "utils-route-priority-"
+
+func newServiceRouter(router string) func() *api.ServiceRouterConfigEntry {
+	return func() *api.ServiceRouterConfigEntry {
+		return &api.ServiceRouterConfigEntry{
+			Kind: api.ServiceRouter,
+			Name: router,
+		}
+	}
+}
+
+func serviceRouterIsEmpty(configEntry *api.ServiceRouterConfigEntry) bool {
+	return len(configEntry.Routes) == 0 && len(configEntry.Meta) == 0
+}
+
+// routeFingerprint identifies a route by its match, since consul only uses
+// the first route matching a request.
+func routeFingerprint(match *api.ServiceRouteMatch) string {
+	encoded, _ := json.Marshal(match)
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// findRoute returns the index of the route with the fingerprint, or -1 when there is none.
+func findRoute(configEntry *api.ServiceRouterConfigEntry, fingerprint string) int {
+	for i, route := range configEntry.Routes {
+		if routeFingerprint(route.Match) == fingerprint {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// routePriority returns the priority recorded for the route with the
+// fingerprint, or false when it is not managed by this provider.
+func routePriority(configEntry *api.ServiceRouterConfigEntry, fingerprint string) (int64, bool) {
+	priority, err := strconv.ParseInt(configEntry.Meta[routePriorityMetaPrefix+fingerprint], 10, 64)
+
+	return priority, err == nil
+}
+
+// removeRoute removes the route with the fingerprint and its priority.
+func removeRoute(configEntry *api.ServiceRouterConfigEntry, fingerprint string) {
+	if i := findRoute(configEntry, fingerprint); i != -1 {
+		configEntry.Routes = append(configEntry.Routes[:i], configEntry.Routes[i+1:]...)
+	}
+
+	delete(configEntry.Meta, routePriorityMetaPrefix+fingerprint)
+}
+
+// insertRoute inserts route after the routes whose priority is lower or equal,
+// the routes not managed by this provider having a priority of 0.
+func insertRoute(configEntry *api.ServiceRouterConfigEntry, route api.ServiceRoute, priority int64) {
+	position := len(configEntry.Routes)
+
+	for i, other := range configEntry.Routes {
+		if otherPriority, _ := routePriority(configEntry, routeFingerprint(other.Match)); otherPriority > priority {
+			position = i
+			break
+		}
+	}
+
+	configEntry.Routes = append(configEntry.Routes[:position], append([]api.ServiceRoute{route}, configEntry.Routes[position:]...)...)
+
+	if configEntry.Meta == nil {
+		configEntry.Meta = make(map[string]string)
+	}
+
+	configEntry.Meta[routePriorityMetaPrefix+routeFingerprint(route.Match)] = strconv.FormatInt(priority, 10)
+}
+
+func NewConsulServiceRouterRouteResource() resource.Resource {
+	return &ConsulServiceRouterRouteResource{}
+}
+
+// ConsulServiceRouterRouteResource defines the resource implementation.
+type ConsulServiceRouterRouteResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceRouterRouteResourceModel describes the resource data model.
+type ConsulServiceRouterRouteResourceModel struct {
+	Cluster     types.String                        `tfsdk:"cluster"`
+	Router      types.String                        `tfsdk:"router"`
+	Priority    types.Int64                         `tfsdk:"priority"`
+	Match       *ConsulServiceRouteMatchModel       `tfsdk:"match"`
+	Destination *ConsulServiceRouteDestinationModel `tfsdk:"destination"`
+	Id          types.String                        `tfsdk:"id"`
+}
+
+// ConsulServiceRouteMatchModel describes the HTTP requests matched by a route.
+type ConsulServiceRouteMatchModel struct {
+	PathExact       types.String                             `tfsdk:"path_exact"`
+	PathPrefix      types.String                             `tfsdk:"path_prefix"`
+	PathRegex       types.String                             `tfsdk:"path_regex"`
+	CaseInsensitive types.Bool                               `tfsdk:"case_insensitive"`
+	Methods         []types.String                           `tfsdk:"methods"`
+	Header          []ConsulServiceRouteMatchHeaderModel     `tfsdk:"header"`
+	QueryParam      []ConsulServiceRouteMatchQueryParamModel `tfsdk:"query_param"`
+}
+
+// ConsulServiceRouteMatchHeaderModel describes a header matched by a route.
+type ConsulServiceRouteMatchHeaderModel struct {
+	Name    types.String `tfsdk:"name"`
+	Present types.Bool   `tfsdk:"present"`
+	Exact   types.String `tfsdk:"exact"`
+	Prefix  types.String `tfsdk:"prefix"`
+	Suffix  types.String `tfsdk:"suffix"`
+	Regex   types.String `tfsdk:"regex"`
+	Invert  types.Bool   `tfsdk:"invert"`
+}
+
+// ConsulServiceRouteMatchQueryParamModel describes a query parameter matched by a route.
+type ConsulServiceRouteMatchQueryParamModel struct {
+	Name    types.String `tfsdk:"name"`
+	Present types.Bool   `tfsdk:"present"`
+	Exact   types.String `tfsdk:"exact"`
+	Regex   types.String `tfsdk:"regex"`
+}
+
+// ConsulServiceRouteDestinationModel describes where a route sends the requests.
+type ConsulServiceRouteDestinationModel struct {
+	Service               types.String  `tfsdk:"service"`
+	ServiceSubset         types.String  `tfsdk:"service_subset"`
+	Namespace             types.String  `tfsdk:"namespace"`
+	Partition             types.String  `tfsdk:"partition"`
+	PrefixRewrite         types.String  `tfsdk:"prefix_rewrite"`
+	RequestTimeout        types.String  `tfsdk:"request_timeout"`
+	IdleTimeout           types.String  `tfsdk:"idle_timeout"`
+	NumRetries            types.Int64   `tfsdk:"num_retries"`
+	RetryOnConnectFailure types.Bool    `tfsdk:"retry_on_connect_failure"`
+	RetryOnStatusCodes    []types.Int64 `tfsdk:"retry_on_status_codes"`
+}
+
+func (r *ConsulServiceRouterRouteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_router_route"
+}
+
+func (r *ConsulServiceRouterRouteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single route of the `service-router` config entry of a service, so that several teams can each contribute their routes to the same router. A route is identified by its `match`, and consul evaluates the routes in ascending `priority`. The routes not managed by this provider have a priority of 0.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"router": schema.StringAttribute{
+				MarkdownDescription: "The name of the service whose `service-router` entry is modified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "The priority of the route. Routes with a lower priority are evaluated first, and routes with the same priority in the order they were added in. Defaults to `0`",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+			},
+			"match": schema.SingleNestedAttribute{
+				MarkdownDescription: "The HTTP requests matched by the route. Matches every request when not set",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"path_exact": schema.StringAttribute{
+						MarkdownDescription: "The exact path of the requests",
+						Optional:            true,
+					},
+					"path_prefix": schema.StringAttribute{
+						MarkdownDescription: "The prefix of the path of the requests",
+						Optional:            true,
+					},
+					"path_regex": schema.StringAttribute{
+						MarkdownDescription: "A regular expression matching the path of the requests",
+						Optional:            true,
+					},
+					"case_insensitive": schema.BoolAttribute{
+						MarkdownDescription: "Whether the path is matched regardless of its case",
+						Optional:            true,
+					},
+					"methods": schema.ListAttribute{
+						MarkdownDescription: "The HTTP methods of the requests",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"header": schema.ListNestedAttribute{
+						MarkdownDescription: "The headers of the requests",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "The name of the header",
+									Required:            true,
+								},
+								"present": schema.BoolAttribute{
+									MarkdownDescription: "Whether the header is present, regardless of its value",
+									Optional:            true,
+								},
+								"exact": schema.StringAttribute{
+									MarkdownDescription: "The exact value of the header",
+									Optional:            true,
+								},
+								"prefix": schema.StringAttribute{
+									MarkdownDescription: "The prefix of the value of the header",
+									Optional:            true,
+								},
+								"suffix": schema.StringAttribute{
+									MarkdownDescription: "The suffix of the value of the header",
+									Optional:            true,
+								},
+								"regex": schema.StringAttribute{
+									MarkdownDescription: "A regular expression matching the value of the header",
+									Optional:            true,
+								},
+								"invert": schema.BoolAttribute{
+									MarkdownDescription: "Whether to match the requests which do not match the header",
+									Optional:            true,
+								},
+							},
+						},
+					},
+					"query_param": schema.ListNestedAttribute{
+						MarkdownDescription: "The query parameters of the requests",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "The name of the query parameter",
+									Required:            true,
+								},
+								"present": schema.BoolAttribute{
+									MarkdownDescription: "Whether the query parameter is present, regardless of its value",
+									Optional:            true,
+								},
+								"exact": schema.StringAttribute{
+									MarkdownDescription: "The exact value of the query parameter",
+									Optional:            true,
+								},
+								"regex": schema.StringAttribute{
+									MarkdownDescription: "A regular expression matching the value of the query parameter",
+									Optional:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"destination": schema.SingleNestedAttribute{
+				MarkdownDescription: "Where the matched requests are sent. Defaults to the router service",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"service": schema.StringAttribute{
+						MarkdownDescription: "The service the requests are sent to. Defaults to the router service",
+						Optional:            true,
+					},
+					"service_subset": schema.StringAttribute{
+						MarkdownDescription: "The subset of the service, as defined in its `service-resolver` entry",
+						Optional:            true,
+					},
+					"namespace": schema.StringAttribute{
+						MarkdownDescription: "The namespace of the service",
+						Optional:            true,
+					},
+					"partition": schema.StringAttribute{
+						MarkdownDescription: "The partition of the service",
+						Optional:            true,
+					},
+					"prefix_rewrite": schema.StringAttribute{
+						MarkdownDescription: "The path replacing the matched `path_exact` or `path_prefix`",
+						Optional:            true,
+					},
+					"request_timeout": schema.StringAttribute{
+						MarkdownDescription: "The timeout of the requests, as a Go duration string",
+						Optional:            true,
+					},
+					"idle_timeout": schema.StringAttribute{
+						MarkdownDescription: "The timeout of the idle requests, as a Go duration string",
+						Optional:            true,
+					},
+					"num_retries": schema.Int64Attribute{
+						MarkdownDescription: "The number of times a failed request is retried",
+						Optional:            true,
+					},
+					"retry_on_connect_failure": schema.BoolAttribute{
+						MarkdownDescription: "Whether to retry the requests failing to connect",
+						Optional:            true,
+					},
+					"retry_on_status_codes": schema.ListAttribute{
+						MarkdownDescription: "The HTTP status codes of the responses causing a retry",
+						ElementType:         types.Int64Type,
+						Optional:            true,
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service router route identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulServiceRouterRouteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// routeMatch returns the match of the route described by data.
+func (data ConsulServiceRouterRouteResourceModel) routeMatch() *api.ServiceRouteMatch {
+	if data.Match == nil {
+		return nil
+	}
+
+	httpMatch := &api.ServiceRouteHTTPMatch{
+		PathExact:       data.Match.PathExact.ValueString(),
+		PathPrefix:      data.Match.PathPrefix.ValueString(),
+		PathRegex:       data.Match.PathRegex.ValueString(),
+		CaseInsensitive: data.Match.CaseInsensitive.ValueBool(),
+	}
+
+	// Consul stores the methods uppercased
+	for _, method := range data.Match.Methods {
+		httpMatch.Methods = append(httpMatch.Methods, strings.ToUpper(method.ValueString()))
+	}
+
+	for _, header := range data.Match.Header {
+		httpMatch.Header = append(httpMatch.Header, api.ServiceRouteHTTPMatchHeader{
+			Name:    header.Name.ValueString(),
+			Present: header.Present.ValueBool(),
+			Exact:   header.Exact.ValueString(),
+			Prefix:  header.Prefix.ValueString(),
+			Suffix:  header.Suffix.ValueString(),
+			Regex:   header.Regex.ValueString(),
+			Invert:  header.Invert.ValueBool(),
+		})
+	}
+
+	for _, queryParam := range data.Match.QueryParam {
+		httpMatch.QueryParam = append(httpMatch.QueryParam, api.ServiceRouteHTTPMatchQueryParam{
+			Name:    queryParam.Name.ValueString(),
+			Present: queryParam.Present.ValueBool(),
+			Exact:   queryParam.Exact.ValueString(),
+			Regex:   queryParam.Regex.ValueString(),
+		})
+	}
+
+	return &api.ServiceRouteMatch{HTTP: httpMatch}
+}
+
+// route returns the route described by data.
+func (data ConsulServiceRouterRouteResourceModel) route() (api.ServiceRoute, error) {
+	route := api.ServiceRoute{
+		Match: data.routeMatch(),
+	}
+
+	if data.Destination == nil {
+		return route, nil
+	}
+
+	requestTimeout, err := parseOptionalDuration("destination.request_timeout", data.Destination.RequestTimeout)
+
+	if err != nil {
+		return route, err
+	}
+
+	idleTimeout, err := parseOptionalDuration("destination.idle_timeout", data.Destination.IdleTimeout)
+
+	if err != nil {
+		return route, err
+	}
+
+	route.Destination = &api.ServiceRouteDestination{
+		Service:               data.Destination.Service.ValueString(),
+		ServiceSubset:         data.Destination.ServiceSubset.ValueString(),
+		Namespace:             data.Destination.Namespace.ValueString(),
+		Partition:             data.Destination.Partition.ValueString(),
+		PrefixRewrite:         data.Destination.PrefixRewrite.ValueString(),
+		RequestTimeout:        requestTimeout,
+		IdleTimeout:           idleTimeout,
+		NumRetries:            uint32(data.Destination.NumRetries.ValueInt64()),
+		RetryOnConnectFailure: data.Destination.RetryOnConnectFailure.ValueBool(),
+	}
+
+	for _, statusCode := range data.Destination.RetryOnStatusCodes {
+		route.Destination.RetryOnStatusCodes = append(route.Destination.RetryOnStatusCodes, uint32(statusCode.ValueInt64()))
+	}
+
+	return route, nil
+}
+
+// optionalBool returns a null bool for the zero value of an optional field,
+// unless it was explicitly set to false before.
+func optionalBool(prior types.Bool, value bool) types.Bool {
+	if !value && prior.IsNull() {
+		return types.BoolNull()
+	}
+
+	return types.BoolValue(value)
+}
+
+// readRouteDestination updates data with the destination read from consul.
+func (data *ConsulServiceRouterRouteResourceModel) readRouteDestination(destination *api.ServiceRouteDestination) {
+	prior := data.Destination
+
+	if prior == nil {
+		prior = &ConsulServiceRouteDestinationModel{}
+	}
+
+	data.Destination = nil
+
+	if destination == nil {
+		return
+	}
+
+	data.Destination = &ConsulServiceRouteDestinationModel{
+		Service:               optionalString(destination.Service),
+		ServiceSubset:         optionalString(destination.ServiceSubset),
+		Namespace:             optionalString(destination.Namespace),
+		Partition:             optionalString(destination.Partition),
+		PrefixRewrite:         optionalString(destination.PrefixRewrite),
+		RequestTimeout:        durationValue(prior.RequestTimeout, destination.RequestTimeout),
+		IdleTimeout:           durationValue(prior.IdleTimeout, destination.IdleTimeout),
+		NumRetries:            optionalInt64(int(destination.NumRetries)),
+		RetryOnConnectFailure: optionalBool(prior.RetryOnConnectFailure, destination.RetryOnConnectFailure),
+	}
+
+	for _, statusCode := range destination.RetryOnStatusCodes {
+		data.Destination.RetryOnStatusCodes = append(data.Destination.RetryOnStatusCodes, types.Int64Value(int64(statusCode)))
+	}
+}
+
+func (data ConsulServiceRouterRouteResourceModel) id() string {
+	return fmt.Sprintf("%s_%s", data.Router.ValueString(), routeFingerprint(data.routeMatch()))
+}
+
+func (r *ConsulServiceRouterRouteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulServiceRouterRouteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	route, err := data.route()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Route", err.Error())
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceRouter, data.Router.ValueString(), newServiceRouter(data.Router.ValueString()), serviceRouterIsEmpty, func(configEntry *api.ServiceRouterConfigEntry) error {
+		if findRoute(configEntry, routeFingerprint(route.Match)) != -1 {
+			return fmt.Errorf("a route with the same match already exists")
+		}
+
+		insertRoute(configEntry, route, data.Priority.ValueInt64())
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service router, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service router route")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceRouterRouteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulServiceRouterRouteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceRouterConfigEntry](client, api.ServiceRouter, data.Router.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service router, got error: %s", err))
+		return
+	}
+
+	fingerprint := routeFingerprint(data.routeMatch())
+
+	i := -1
+
+	if found {
+		i = findRoute(configEntry, fingerprint)
+	}
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if priority, ok := routePriority(configEntry, fingerprint); ok {
+		data.Priority = types.Int64Value(priority)
+	}
+
+	data.readRouteDestination(configEntry.Routes[i].Destination)
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceRouterRouteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulServiceRouterRouteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	route, err := data.route()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Route", err.Error())
+		return
+	}
+
+	// The match requires a replacement, so the route is replaced in the same
+	// write and requests are always routed by either version of it
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceRouter, data.Router.ValueString(), newServiceRouter(data.Router.ValueString()), serviceRouterIsEmpty, func(configEntry *api.ServiceRouterConfigEntry) error {
+		removeRoute(configEntry, routeFingerprint(route.Match))
+		insertRoute(configEntry, route, data.Priority.ValueInt64())
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service router, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service router route")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceRouterRouteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulServiceRouterRouteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceRouter, data.Router.ValueString(), newServiceRouter(data.Router.ValueString()), serviceRouterIsEmpty, func(configEntry *api.ServiceRouterConfigEntry) error {
+		removeRoute(configEntry, routeFingerprint(data.routeMatch()))
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service router, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceRouterRouteResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+# Consul only routes the requests of services speaking http
+resource "utils_consul_service_defaults_field" "protocol" {
+	for_each = toset(["invalid-service", "invalid-admin"])
+
+	service  = each.key
+	protocol = "http"
+}
+
+resource "utils_consul_service_router_route" "test" {
+	router = "invalid-service"
+
+	match = {
+		path_prefix = "/admin"
+	}
+
+	destination = {
+		service         = "invalid-admin"
+		request_timeout = "10s"
+	}
+
+	depends_on = [utils_consul_service_defaults_field.protocol]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_router_route.test", "priority", "0"),
+					resource.TestCheckResourceAttr("utils_consul_service_router_route.test", "destination.request_timeout", "10s"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+# Consul only routes the requests of services speaking http
+resource "utils_consul_service_defaults_field" "protocol" {
+	for_each = toset(["invalid-service", "invalid-admin"])
+
+	service  = each.key
+	protocol = "http"
+}
+
+resource "utils_consul_service_router_route" "test" {
+	router   = "invalid-service"
+	priority = 10
+
+	match = {
+		path_prefix = "/admin"
+		methods     = ["get"]
+	}
+
+	destination = {
+		service     = "invalid-admin"
+		num_retries = 3
+	}
+
+	depends_on = [utils_consul_service_defaults_field.protocol]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_router_route.test", "priority", "10"),
+					resource.TestCheckResourceAttr("utils_consul_service_router_route.test", "destination.num_retries", "3"),
+				),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func testRouteModel(priority int64, pathPrefix string) ConsulServiceRouterRouteResourceModel {
+	return ConsulServiceRouterRouteResourceModel{
+		Router:   types.StringValue("web"),
+		Priority: types.Int64Value(priority),
+		Match:    &ConsulServiceRouteMatchModel{PathPrefix: types.StringValue(pathPrefix)},
+		Destination: &ConsulServiceRouteDestinationModel{
+			Service: types.StringValue("web" + pathPrefix),
+		},
+	}
+}
+
+func TestInsertRouteByPriority(t *testing.T) {
+	client, _ := newTestConfigEntryClient(t)
+
+	// A route written by hand, with the default priority
+	_, _, err := client.ConfigEntries().Set(&api.ServiceRouterConfigEntry{
+		Kind:   api.ServiceRouter,
+		Name:   "web",
+		Routes: []api.ServiceRoute{{Match: &api.ServiceRouteMatch{HTTP: &api.ServiceRouteHTTPMatch{PathPrefix: "/manual"}}}},
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	insert := func(data ConsulServiceRouterRouteResourceModel) {
+		t.Helper()
+
+		route, err := data.route()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.ServiceRouter, "web", newServiceRouter("web"), serviceRouterIsEmpty, func(configEntry *api.ServiceRouterConfigEntry) error {
+			insertRoute(configEntry, route, data.Priority.ValueInt64())
+			return nil
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	insert(testRouteModel(10, "/last"))
+	insert(testRouteModel(-1, "/first"))
+	insert(testRouteModel(0, "/after-manual"))
+
+	configEntry, _, err := readTypedConfigEntry[*api.ServiceRouterConfigEntry](client, api.ServiceRouter, "web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var order []string
+
+	for _, route := range configEntry.Routes {
+		order = append(order, route.Match.HTTP.PathPrefix)
+	}
+
+	expected := []string{"/first", "/manual", "/after-manual", "/last"}
+
+	if len(order) != len(expected) {
+		t.Fatalf("expected routes %v, got %v", expected, order)
+	}
+
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected routes %v, got %v", expected, order)
+		}
+	}
+
+	// The routes read back from consul are found by the match of their configuration
+	data := testRouteModel(10, "/last")
+	fingerprint := routeFingerprint(data.routeMatch())
+
+	if i := findRoute(configEntry, fingerprint); i != 3 {
+		t.Errorf("expected the route to be found at 3, got %d", i)
+	}
+
+	if priority, ok := routePriority(configEntry, fingerprint); !ok || priority != 10 {
+		t.Errorf("expected the priority to be recorded, got %d", priority)
+	}
+}
+
+func TestRouteMatchUppercasesMethods(t *testing.T) {
+	data := testRouteModel(0, "/admin")
+	data.Match.Methods = []types.String{types.StringValue("get"), types.StringValue("Post")}
+
+	// The match read back from consul has its methods uppercased
+	stored := &api.ServiceRouteMatch{HTTP: &api.ServiceRouteHTTPMatch{PathPrefix: "/admin", Methods: []string{"GET", "POST"}}}
+
+	if routeFingerprint(data.routeMatch()) != routeFingerprint(stored) {
+		t.Errorf("expected the route to be found with the methods of its configuration, got %+v", data.routeMatch().HTTP.Methods)
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+)
+
+const defaultSplitCoordinationTimeout = "30s"
+
+// The weights of the splits are compared in hundredths, the precision consul keeps
+const splitsTotalWeight = 10000
+
+// errSplitsIncomplete is returned when the weights of the splits do not sum to 100 yet.
+var errSplitsIncomplete = errors.New("the weights of the splits do not sum to 100")
+
+// splitChange is the change of one split planned or applied by a resource.
+type splitChange struct {
+	Service       string
+	ServiceSubset string
+	Weight        float32
+	// Whether the split is removed rather than added or updated
+	Remove bool
+
+	// Receives the result of the write including the change
+	done chan error
+}
+
+func (c *splitChange) key() string {
+	return c.Service + "/" + c.ServiceSubset
+}
+
+func newServiceSplitter(splitter string) func() *api.ServiceSplitterConfigEntry {
+	return func() *api.ServiceSplitterConfigEntry {
+		return &api.ServiceSplitterConfigEntry{
+			Kind: api.ServiceSplitter,
+			Name: splitter,
+		}
+	}
+}
+
+func serviceSplitterIsEmpty(configEntry *api.ServiceSplitterConfigEntry) bool {
+	return len(configEntry.Splits) == 0
+}
+
+// findSplit returns the index of the split of the service subset, or -1 when there is none.
+func findSplit(configEntry *api.ServiceSplitterConfigEntry, service, serviceSubset string) int {
+	for i, split := range configEntry.Splits {
+		if split.Service == service && split.ServiceSubset == serviceSubset {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// applySplitChanges applies changes to the splits, updating the weight of
+// the existing ones in place.
+func applySplitChanges(configEntry *api.ServiceSplitterConfigEntry, changes map[string]*splitChange) {
+	keys := make([]string, 0, len(changes))
+
+	for key := range changes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		change := changes[key]
+		i := findSplit(configEntry, change.Service, change.ServiceSubset)
+
+		switch {
+		case change.Remove && i != -1:
+			configEntry.Splits = append(configEntry.Splits[:i], configEntry.Splits[i+1:]...)
+		case change.Remove:
+		case i != -1:
+			configEntry.Splits[i].Weight = change.Weight
+		default:
+			configEntry.Splits = append(configEntry.Splits, api.ServiceSplit{
+				Service:       change.Service,
+				ServiceSubset: change.ServiceSubset,
+				Weight:        change.Weight,
+			})
+		}
+	}
+}
+
+// splitsWeight returns the sum of the weights of the splits, in hundredths.
+func splitsWeight(configEntry *api.ServiceSplitterConfigEntry) int64 {
+	var total int64
+
+	for _, split := range configEntry.Splits {
+		total += int64(math.Round(float64(split.Weight) * 100))
+	}
+
+	return total
+}
+
+// splitsAreValid tells whether consul accepts the splits, an entry without
+// splits being deleted.
+func splitsAreValid(configEntry *api.ServiceSplitterConfigEntry) bool {
+	return len(configEntry.Splits) == 0 || splitsWeight(configEntry) == splitsTotalWeight
+}
+
+func splitsWeightError(configEntry *api.ServiceSplitterConfigEntry) error {
+	return fmt.Errorf("the weights of the splits of service-splitter %q sum to %.2f instead of 100", configEntry.Name, float64(splitsWeight(configEntry))/100)
+}
+
+// splitCoordinator gathers the changes of the splits of each service-splitter
+// entry, so that the resources converging on the same entry are checked and
+// written together, since consul rejects the weights which do not sum to 100.
+type splitCoordinator struct {
+	lock sync.Mutex
+	// Changes by entry, then by split
+	changes map[string]map[string]*splitChange
+	// Closed when the changes of the entry change
+	wake map[string]chan struct{}
+	// Closed when the write in progress of the entry completes
+	writing map[string]chan struct{}
+}
+
+func newSplitCoordinator() *splitCoordinator {
+	return &splitCoordinator{
+		changes: make(map[string]map[string]*splitChange),
+		wake:    make(map[string]chan struct{}),
+		writing: make(map[string]chan struct{}),
+	}
+}
+
+// Resources of the same plan, or of the same apply, share the provider process
+var splitPlans = newSplitCoordinator()
+var splitApplies = newSplitCoordinator()
+
+// wakeChannel returns the channel closed on the next change of the entry.
+// The lock must be held.
+func (c *splitCoordinator) wakeChannel(entryKey string) chan struct{} {
+	if _, ok := c.wake[entryKey]; !ok {
+		c.wake[entryKey] = make(chan struct{})
+	}
+
+	return c.wake[entryKey]
+}
+
+// record records changes, replacing the previous changes of the same splits,
+// and wakes the resources waiting for the changes of the entry. The lock must be held.
+func (c *splitCoordinator) record(entryKey string, changes []*splitChange) {
+	if c.changes[entryKey] == nil {
+		c.changes[entryKey] = make(map[string]*splitChange)
+	}
+
+	for _, change := range changes {
+		c.changes[entryKey][change.key()] = change
+	}
+
+	close(c.wakeChannel(entryKey))
+	delete(c.wake, entryKey)
+}
+
+// register records changes, failing when another resource already has a
+// pending change of the same split, since only one of them could be written.
+func (c *splitCoordinator) register(entryKey string, changes []*splitChange) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, change := range changes {
+		if _, ok := c.changes[entryKey][change.key()]; ok {
+			return fmt.Errorf("the split of service %q and subset %q is already changed by another resource", change.Service, change.ServiceSubset)
+		}
+	}
+
+	c.record(entryKey, changes)
+
+	return nil
+}
+
+// snapshot returns a copy of the changes of the entry, and the channel closed on their next change.
+func (c *splitCoordinator) snapshot(entryKey string) (map[string]*splitChange, chan struct{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	changes := make(map[string]*splitChange, len(c.changes[entryKey]))
+
+	for key, change := range c.changes[entryKey] {
+		changes[key] = change
+	}
+
+	return changes, c.wakeChannel(entryKey)
+}
+
+// checkPlan records the planned changes, then waits until the splits of
+// configEntry, with the changes planned by every resource so far, are valid.
+// A split planned again, as terraform does before applying it, replaces its
+// previous plan.
+func (c *splitCoordinator) checkPlan(ctx context.Context, entryKey string, configEntry *api.ServiceSplitterConfigEntry, timeout time.Duration, changes ...*splitChange) error {
+	c.lock.Lock()
+	c.record(entryKey, changes)
+	c.lock.Unlock()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		planned, wake := c.snapshot(entryKey)
+
+		candidate := *configEntry
+		candidate.Splits = append([]api.ServiceSplit(nil), configEntry.Splits...)
+
+		applySplitChanges(&candidate, planned)
+
+		if splitsAreValid(&candidate) {
+			return nil
+		}
+
+		select {
+		case <-wake:
+		case <-deadline.C:
+			return splitsWeightError(&candidate)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// apply registers changes, then waits until write has written them with the
+// changes of the other resources. write is given all the pending changes of
+// the entry, and returns errSplitsIncomplete while their weights do not sum
+// to 100. It is called without holding the lock, by one resource of the
+// entry at a time, so that the writes of other entries are not blocked.
+func (c *splitCoordinator) apply(ctx context.Context, entryKey string, timeout time.Duration, write func(changes map[string]*splitChange) error, changes ...*splitChange) error {
+	// The changes are registered together, so they are always written together
+	done := make(chan error, len(changes))
+
+	for _, change := range changes {
+		change.done = done
+	}
+
+	if err := c.register(entryKey, changes); err != nil {
+		return err
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	// Until a write including the changes is tried
+	lastErr := errSplitsIncomplete
+
+	for {
+		c.lock.Lock()
+
+		wake := c.wakeChannel(entryKey)
+		writing := c.writing[entryKey]
+
+		// Otherwise the changes are being written, or were written, by another resource
+		if writing == nil && c.isPending(entryKey, changes[0]) {
+			pending := make(map[string]*splitChange, len(c.changes[entryKey]))
+
+			for key, change := range c.changes[entryKey] {
+				pending[key] = change
+			}
+
+			finished := make(chan struct{})
+			c.writing[entryKey] = finished
+
+			c.lock.Unlock()
+
+			lastErr = write(pending)
+
+			c.lock.Lock()
+
+			// The changes registered during the write stay pending
+			if !errors.Is(lastErr, errSplitsIncomplete) {
+				for key, written := range pending {
+					written.done <- lastErr
+					delete(c.changes[entryKey], key)
+				}
+			}
+
+			delete(c.writing, entryKey)
+			close(finished)
+		}
+
+		c.lock.Unlock()
+
+		var waitErr error
+
+		select {
+		case err := <-done:
+			return err
+		case <-wake:
+			continue
+		case <-writing:
+			continue
+		case <-deadline.C:
+			waitErr = lastErr
+		case <-ctx.Done():
+			waitErr = ctx.Err()
+		}
+
+		return c.abandon(entryKey, changes, done, waitErr)
+	}
+}
+
+// abandon withdraws the changes which are still pending once the resource
+// stops waiting, after any write in progress completes, since it may include them.
+func (c *splitCoordinator) abandon(entryKey string, changes []*splitChange, done chan error, waitErr error) error {
+	c.lock.Lock()
+
+	for c.writing[entryKey] != nil {
+		writing := c.writing[entryKey]
+
+		c.lock.Unlock()
+		<-writing
+		c.lock.Lock()
+	}
+
+	defer c.lock.Unlock()
+
+	if !c.isPending(entryKey, changes[0]) {
+		return <-done
+	}
+
+	for _, change := range changes {
+		delete(c.changes[entryKey], change.key())
+	}
+
+	return waitErr
+}
+
+// isPending tells whether change is not written yet. The lock must be held.
+func (c *splitCoordinator) isPending(entryKey string, change *splitChange) bool {
+	return c.changes[entryKey][change.key()] == change
+}
@@ -0,0 +1,401 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulServiceSplitterSplitResource{}
+var _ resource.ResourceWithImportState = &ConsulServiceSplitterSplitResource{}
+var _ resource.ResourceWithModifyPlan = &ConsulServiceSplitterSplitResource{}
+
+func NewConsulServiceSplitterSplitResource() resource.Resource {
+	return &ConsulServiceSplitterSplitResource{}
+}
+
+// ConsulServiceSplitterSplitResource defines the resource implementation.
+type ConsulServiceSplitterSplitResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceSplitterSplitResourceModel describes the resource data model.
+type ConsulServiceSplitterSplitResourceModel struct {
+	Cluster             types.String  `tfsdk:"cluster"`
+	Splitter            types.String  `tfsdk:"splitter"`
+	Service             types.String  `tfsdk:"service"`
+	ServiceSubset       types.String  `tfsdk:"service_subset"`
+	Weight              types.Float64 `tfsdk:"weight"`
+	CoordinationTimeout types.String  `tfsdk:"coordination_timeout"`
+	Id                  types.String  `tfsdk:"id"`
+}
+
+func (r *ConsulServiceSplitterSplitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_splitter_split"
+}
+
+func (r *ConsulServiceSplitterSplitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single split of the `service-splitter` config entry of a service, such as the canary of a release. " +
+			"The plan fails when the weights of the splits of the entry would not sum to 100, and the splits of the same entry changed by an apply are written together, so consul never sees weights which do not sum to 100. " +
+			"Since the splits of an entry wait for each other both when they are planned and when they are applied, the `-parallelism` of terraform must be at least the number of splits of an entry changed together, " +
+			"otherwise the splits planned or applied first wait for `coordination_timeout` and fail, as with `-parallelism=1`.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"splitter": schema.StringAttribute{
+				MarkdownDescription: "The name of the service whose `service-splitter` entry is modified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The service the split sends the requests to",
+				Required:            true,
+			},
+			"service_subset": schema.StringAttribute{
+				MarkdownDescription: "The subset of the service, as defined in its `service-resolver` entry",
+				Optional:            true,
+			},
+			"weight": schema.Float64Attribute{
+				MarkdownDescription: "The percentage of the requests sent to the split, with up to 2 decimals",
+				Required:            true,
+			},
+			"coordination_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the other splits of the entry to be planned or applied, as a Go duration string. Defaults to `" + defaultSplitCoordinationTimeout + "`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultSplitCoordinationTimeout),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service splitter split identifier",
+			},
+		},
+	}
+}
+
+func (r *ConsulServiceSplitterSplitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// entryKey identifies the service-splitter entry of the split across clusters.
+func (data ConsulServiceSplitterSplitResourceModel) entryKey() string {
+	return data.Cluster.ValueString() + "/" + data.Splitter.ValueString()
+}
+
+func (data ConsulServiceSplitterSplitResourceModel) change(remove bool) *splitChange {
+	return &splitChange{
+		Service:       data.Service.ValueString(),
+		ServiceSubset: data.ServiceSubset.ValueString(),
+		Weight:        float32(data.Weight.ValueFloat64()),
+		Remove:        remove,
+	}
+}
+
+func (data ConsulServiceSplitterSplitResourceModel) id() string {
+	if !data.ServiceSubset.IsNull() {
+		return fmt.Sprintf("%s_%s_%s", data.Splitter.ValueString(), data.Service.ValueString(), data.ServiceSubset.ValueString())
+	}
+
+	return fmt.Sprintf("%s_%s", data.Splitter.ValueString(), data.Service.ValueString())
+}
+
+func (data ConsulServiceSplitterSplitResourceModel) coordinationTimeout() (time.Duration, error) {
+	timeout, err := time.ParseDuration(data.CoordinationTimeout.ValueString())
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordination_timeout %q: %w", data.CoordinationTimeout.ValueString(), err)
+	}
+
+	return timeout, nil
+}
+
+func (r *ConsulServiceSplitterSplitResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider is not configured yet when validating the configuration
+	if r.providerData == nil {
+		return
+	}
+
+	var data ConsulServiceSplitterSplitResourceModel
+	var oldData ConsulServiceSplitterSplitResourceModel
+
+	var changes []*splitChange
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+	}
+
+	if req.Plan.Raw.IsNull() {
+		// The split is destroyed
+		data = oldData
+		changes = append(changes, oldData.change(true))
+	} else {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Variables are only known once they are applied
+		if data.Cluster.IsUnknown() || data.Splitter.IsUnknown() || data.Service.IsUnknown() || data.ServiceSubset.IsUnknown() || data.Weight.IsUnknown() || data.CoordinationTimeout.IsUnknown() {
+			return
+		}
+
+		changes = append(changes, data.change(false))
+
+		// The identifier changes with the service of the split
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), data.id())...)
+
+		// A split moved to another service of the same entry is removed from it
+		if !req.State.Raw.IsNull() && oldData.entryKey() == data.entryKey() && oldData.change(true).key() != data.change(true).key() {
+			changes = append(changes, oldData.change(true))
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := data.coordinationTimeout()
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("coordination_timeout"), "Invalid Coordination Timeout", err.Error())
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceSplitterConfigEntry](client, api.ServiceSplitter, data.Splitter.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service splitter, got error: %s", err))
+		return
+	}
+
+	if !found {
+		configEntry = newServiceSplitter(data.Splitter.ValueString())()
+	}
+
+	err = splitPlans.checkPlan(ctx, data.entryKey(), configEntry, timeout, changes...)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("weight"), "Invalid Split Weights", fmt.Sprintf("Once every split of the plan is applied, %s.", err))
+	}
+}
+
+// applyChanges writes changes with the changes of the other splits of the same entry.
+func (r *ConsulServiceSplitterSplitResource) applyChanges(ctx context.Context, client *api.Client, data ConsulServiceSplitterSplitResourceModel, changes ...*splitChange) error {
+	timeout, err := data.coordinationTimeout()
+
+	if err != nil {
+		return err
+	}
+
+	return splitApplies.apply(ctx, data.entryKey(), timeout, func(changes map[string]*splitChange) error {
+		return updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceSplitter, data.Splitter.ValueString(), newServiceSplitter(data.Splitter.ValueString()), serviceSplitterIsEmpty, func(configEntry *api.ServiceSplitterConfigEntry) error {
+			applySplitChanges(configEntry, changes)
+
+			if !splitsAreValid(configEntry) {
+				return fmt.Errorf("%w: %w", errSplitsIncomplete, splitsWeightError(configEntry))
+			}
+
+			return nil
+		})
+	}, changes...)
+}
+
+func (r *ConsulServiceSplitterSplitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulServiceSplitterSplitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = r.applyChanges(ctx, client, data, data.change(false))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service splitter, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service splitter split")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceSplitterSplitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulServiceSplitterSplitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceSplitterConfigEntry](client, api.ServiceSplitter, data.Splitter.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service splitter, got error: %s", err))
+		return
+	}
+
+	i := -1
+
+	if found {
+		i = findSplit(configEntry, data.Service.ValueString(), data.ServiceSubset.ValueString())
+	}
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Weights are stored as float32, so the prior value is kept when they are equal at this precision
+	if data.Weight.IsNull() || float32(data.Weight.ValueFloat64()) != configEntry.Splits[i].Weight {
+		data.Weight = types.Float64Value(float64(configEntry.Splits[i].Weight))
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceSplitterSplitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulServiceSplitterSplitResourceModel
+	var oldData ConsulServiceSplitterSplitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	changes := []*splitChange{data.change(false)}
+
+	// A split moved to another service is removed in the same write, since
+	// a replacement would remove it before any other split is updated
+	if oldData.change(true).key() != data.change(true).key() {
+		changes = append(changes, oldData.change(true))
+	}
+
+	err = r.applyChanges(ctx, client, data, changes...)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service splitter, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service splitter split")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceSplitterSplitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulServiceSplitterSplitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = r.applyChanges(ctx, client, data, data.change(true))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service splitter, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulServiceSplitterSplitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromID(ctx, req, resp, []string{"splitter", "service"}, []string{"splitter", "service", "service_subset"})
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("coordination_timeout"), defaultSplitCoordinationTimeout)...)
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceSplitterSplitResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				// Consul only splits services speaking http, across the subsets of their service-resolver
+				PreConfig: func() {
+					testAccWriteConfigEntries(t,
+						&api.ServiceConfigEntry{Kind: api.ServiceDefaults, Name: "invalid-service", Protocol: "http"},
+						&api.ServiceResolverConfigEntry{
+							Kind: api.ServiceResolver,
+							Name: "invalid-service",
+							Subsets: map[string]api.ServiceResolverSubset{
+								"stable": {Filter: "Service.Meta.version == stable"},
+								"canary": {Filter: "Service.Meta.version == canary"},
+							},
+						},
+					)
+				},
+				Config: testAccConsulServiceSplitterSplitResourceConfig(90, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_splitter_split.stable", "weight", "90"),
+					resource.TestCheckResourceAttr("utils_consul_service_splitter_split.canary", "id", "invalid-service_invalid-service_canary"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccConsulServiceSplitterSplitResourceConfig(50, 50),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_splitter_split.stable", "weight", "50"),
+					resource.TestCheckResourceAttr("utils_consul_service_splitter_split.canary", "weight", "50"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_service_splitter_split.canary",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The coordination timeout is not stored in consul
+				ImportStateVerifyIgnore: []string{"coordination_timeout"},
+			},
+			{
+				Config:      testAccConsulServiceSplitterSplitResourceConfig(50, 40),
+				ExpectError: regexp.MustCompile("Invalid Split Weights"),
+			},
+			// Delete testing
+		},
+	})
+}
+
+func testAccConsulServiceSplitterSplitResourceConfig(stable, canary int) string {
+	return fmt.Sprintf(`
+resource "utils_consul_service_splitter_split" "stable" {
+	splitter             = "invalid-service"
+	service              = "invalid-service"
+	service_subset       = "stable"
+	weight               = %[1]d
+	coordination_timeout = "5s"
+}
+
+resource "utils_consul_service_splitter_split" "canary" {
+	splitter             = "invalid-service"
+	service              = "invalid-service"
+	service_subset       = "canary"
+	weight               = %[2]d
+	coordination_timeout = "5s"
+}
+`, stable, canary)
+}
+
+func testSplitter(weights map[string]float32) *api.ServiceSplitterConfigEntry {
+	configEntry := newServiceSplitter("web")()
+
+	for subset, weight := range weights {
+		configEntry.Splits = append(configEntry.Splits, api.ServiceSplit{Service: "web", ServiceSubset: subset, Weight: weight})
+	}
+
+	return configEntry
+}
+
+func TestSplitCoordinatorCheckPlan(t *testing.T) {
+	coordinator := newSplitCoordinator()
+	configEntry := testSplitter(map[string]float32{"stable": 90, "canary": 10})
+
+	var wg sync.WaitGroup
+	var stableErr error
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		stableErr = coordinator.checkPlan(context.Background(), "web", configEntry, time.Second, &splitChange{Service: "web", ServiceSubset: "stable", Weight: 50})
+	}()
+
+	// The first split waits for the second one to be planned
+	canaryErr := coordinator.checkPlan(context.Background(), "web", configEntry, time.Second, &splitChange{Service: "web", ServiceSubset: "canary", Weight: 50})
+
+	wg.Wait()
+
+	if stableErr != nil || canaryErr != nil {
+		t.Errorf("expected the plan to be valid, got %v and %v", stableErr, canaryErr)
+	}
+
+	err := coordinator.checkPlan(context.Background(), "web", configEntry, 10*time.Millisecond, &splitChange{Service: "web", ServiceSubset: "canary", Weight: 40})
+
+	if err == nil {
+		t.Error("expected the weights summing to 90 to be rejected")
+	}
+}
+
+func TestSplitCoordinatorApply(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	_, _, err := client.ConfigEntries().Set(testSplitter(map[string]float32{"stable": 90, "canary": 10}), nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coordinator := newSplitCoordinator()
+
+	write := func(changes map[string]*splitChange) error {
+		// The other entries are not blocked during the write
+		unlocked := make(chan struct{})
+
+		go func() {
+			coordinator.lock.Lock()
+			coordinator.lock.Unlock()
+			close(unlocked)
+		}()
+
+		select {
+		case <-unlocked:
+		case <-time.After(time.Second):
+			t.Error("expected the coordinator not to be locked while writing")
+		}
+
+		return updateConfigEntryFragment(context.Background(), client, testRetryPolicy, api.ServiceSplitter, "web", newServiceSplitter("web"), serviceSplitterIsEmpty, func(configEntry *api.ServiceSplitterConfigEntry) error {
+			applySplitChanges(configEntry, changes)
+
+			if !splitsAreValid(configEntry) {
+				return errSplitsIncomplete
+			}
+
+			return nil
+		})
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+
+	// The stable split moves to another subset, while the canary one is promoted
+	changes := [][]*splitChange{
+		{{Service: "web", ServiceSubset: "stable", Remove: true}},
+		{{Service: "web", ServiceSubset: "canary", Weight: 60}},
+		{{Service: "web", ServiceSubset: "next", Weight: 40}},
+	}
+
+	for i := range changes {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = coordinator.apply(context.Background(), "web", time.Second, write, changes[i]...)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("change %d: %s", i, err)
+		}
+	}
+
+	// The initial entry, then the three changes at once
+	if store.writes != 2 {
+		t.Errorf("expected the changes to be written at once, got %d writes", store.writes)
+	}
+
+	err = coordinator.apply(context.Background(), "web", 10*time.Millisecond, write, &splitChange{Service: "web", ServiceSubset: "next", Remove: true})
+
+	if !errors.Is(err, errSplitsIncomplete) {
+		t.Errorf("expected the removal of a split alone to time out, got %v", err)
+	}
+
+	if store.writes != 2 {
+		t.Errorf("expected no invalid write, got %d writes", store.writes)
+	}
+}
+
+func TestSplitCoordinatorApplySameSplit(t *testing.T) {
+	coordinator := newSplitCoordinator()
+
+	// The weights never sum to 100, so the changes stay pending
+	write := func(changes map[string]*splitChange) error {
+		return errSplitsIncomplete
+	}
+
+	firstErr := make(chan error, 1)
+
+	go func() {
+		firstErr <- coordinator.apply(context.Background(), "web", 200*time.Millisecond, write, &splitChange{Service: "web", ServiceSubset: "canary", Weight: 10})
+	}()
+
+	for {
+		coordinator.lock.Lock()
+		registered := len(coordinator.changes["web"]) > 0
+		coordinator.lock.Unlock()
+
+		if registered {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	err := coordinator.apply(context.Background(), "web", time.Second, write, &splitChange{Service: "web", ServiceSubset: "canary", Weight: 20})
+
+	if err == nil || errors.Is(err, errSplitsIncomplete) {
+		t.Errorf("expected a second change of the same split to be rejected, got %v", err)
+	}
+
+	select {
+	case err := <-firstErr:
+		if !errors.Is(err, errSplitsIncomplete) {
+			t.Errorf("expected the first change to time out, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the first change not to hang")
+	}
+}
+
+func TestConsulServiceSplitterSplitResourceImportState(t *testing.T) {
+	r := &ConsulServiceSplitterSplitResource{}
+
+	testImportState(t, r, "web_web_canary", map[string]attr.Value{
+		"splitter":             types.StringValue("web"),
+		"service":              types.StringValue("web"),
+		"service_subset":       types.StringValue("canary"),
+		"coordination_timeout": types.StringValue(defaultSplitCoordinationTimeout),
+		"id":                   types.StringValue("web_web_canary"),
+	})
+
+	testImportState(t, r, "web_web-v2", map[string]attr.Value{
+		"service":        types.StringValue("web-v2"),
+		"service_subset": types.StringNull(),
+	})
+
+	testImportState(t, r, "web_web_canary_other", nil)
+}
@@ -0,0 +1,530 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulServiceUpstreamOverrideResource{}
+var _ resource.ResourceWithImportState = &ConsulServiceUpstreamOverrideResource{}
+
+// findUpstreamOverride returns the index of the override of the upstream name
+// reached through peer, or -1 when there is none.
+func findUpstreamOverride(configEntry *api.ServiceConfigEntry, name, peer string) int {
+	if configEntry.UpstreamConfig == nil {
+		return -1
+	}
+
+	for i, override := range configEntry.UpstreamConfig.Overrides {
+		if override.Name == name && override.Peer == peer {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// setUpstreamOverride replaces the override of the upstream of newOverride, or adds it.
+func setUpstreamOverride(configEntry *api.ServiceConfigEntry, newOverride *api.UpstreamConfig) {
+	if configEntry.UpstreamConfig == nil {
+		configEntry.UpstreamConfig = &api.UpstreamConfiguration{}
+	}
+
+	if i := findUpstreamOverride(configEntry, newOverride.Name, newOverride.Peer); i != -1 {
+		configEntry.UpstreamConfig.Overrides[i] = newOverride
+		return
+	}
+
+	configEntry.UpstreamConfig.Overrides = append(configEntry.UpstreamConfig.Overrides, newOverride)
+}
+
+// removeUpstreamOverride removes the override of the upstream name reached through peer.
+func removeUpstreamOverride(configEntry *api.ServiceConfigEntry, name, peer string) {
+	if i := findUpstreamOverride(configEntry, name, peer); i != -1 {
+		configEntry.UpstreamConfig.Overrides = append(configEntry.UpstreamConfig.Overrides[:i], configEntry.UpstreamConfig.Overrides[i+1:]...)
+	}
+}
+
+func NewConsulServiceUpstreamOverrideResource() resource.Resource {
+	return &ConsulServiceUpstreamOverrideResource{}
+}
+
+// ConsulServiceUpstreamOverrideResource defines the resource implementation.
+type ConsulServiceUpstreamOverrideResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulServiceUpstreamOverrideResourceModel describes the resource data model.
+type ConsulServiceUpstreamOverrideResourceModel struct {
+	Cluster            types.String                   `tfsdk:"cluster"`
+	Service            types.String                   `tfsdk:"service"`
+	Upstream           types.String                   `tfsdk:"upstream"`
+	Peer               types.String                   `tfsdk:"peer"`
+	Protocol           types.String                   `tfsdk:"protocol"`
+	ConnectTimeoutMs   types.Int64                    `tfsdk:"connect_timeout_ms"`
+	Limits             *ConsulUpstreamLimitsModel     `tfsdk:"limits"`
+	PassiveHealthCheck *ConsulPassiveHealthCheckModel `tfsdk:"passive_health_check"`
+	Id                 types.String                   `tfsdk:"id"`
+}
+
+// ConsulUpstreamLimitsModel describes the limits of the connections to an upstream.
+type ConsulUpstreamLimitsModel struct {
+	MaxConnections        types.Int64 `tfsdk:"max_connections"`
+	MaxPendingRequests    types.Int64 `tfsdk:"max_pending_requests"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+}
+
+// ConsulPassiveHealthCheckModel describes how the instances of an upstream are ejected.
+type ConsulPassiveHealthCheckModel struct {
+	Interval                types.String `tfsdk:"interval"`
+	MaxFailures             types.Int64  `tfsdk:"max_failures"`
+	EnforcingConsecutive5xx types.Int64  `tfsdk:"enforcing_consecutive_5xx"`
+	MaxEjectionPercent      types.Int64  `tfsdk:"max_ejection_percent"`
+	BaseEjectionTime        types.String `tfsdk:"base_ejection_time"`
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_service_upstream_override"
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single entry of `UpstreamConfig.Overrides` in the `service-defaults` config entry of a service, so that each team consuming an upstream can own its override. The other overrides and fields of the entry are left untouched, and creating the resource fails when the override of the upstream already exists.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The name of the service whose `service-defaults` entry is modified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"upstream": schema.StringAttribute{
+				MarkdownDescription: "The name of the upstream service the override applies to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"peer": schema.StringAttribute{
+				MarkdownDescription: "The peer the upstream service is imported from",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "The protocol of the upstream service, such as `tcp`, `http` or `grpc`",
+				Optional:            true,
+			},
+			"connect_timeout_ms": schema.Int64Attribute{
+				MarkdownDescription: "How long to wait for a new connection to the upstream service, in milliseconds",
+				Optional:            true,
+			},
+			"limits": schema.SingleNestedAttribute{
+				MarkdownDescription: "The limits of the connections of the proxy to the upstream service",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"max_connections": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of connections to the upstream service",
+						Optional:            true,
+					},
+					"max_pending_requests": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of requests queued while waiting for a connection",
+						Optional:            true,
+					},
+					"max_concurrent_requests": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of in-flight requests to the upstream service",
+						Optional:            true,
+					},
+				},
+			},
+			"passive_health_check": schema.SingleNestedAttribute{
+				MarkdownDescription: "How the instances of the upstream service are ejected from the load balancing pool when they fail",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"interval": schema.StringAttribute{
+						MarkdownDescription: "The interval between two analyses of the instances, as a Go duration string",
+						Optional:            true,
+					},
+					"max_failures": schema.Int64Attribute{
+						MarkdownDescription: "The number of consecutive failures ejecting an instance",
+						Optional:            true,
+					},
+					"enforcing_consecutive_5xx": schema.Int64Attribute{
+						MarkdownDescription: "The percent chance that an instance is ejected after consecutive 5xx responses",
+						Optional:            true,
+					},
+					"max_ejection_percent": schema.Int64Attribute{
+						MarkdownDescription: "The maximum percent of the instances that can be ejected",
+						Optional:            true,
+					},
+					"base_ejection_time": schema.StringAttribute{
+						MarkdownDescription: "How long an instance is ejected for the first time, as a Go duration string",
+						Optional:            true,
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Upstream override identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// optionalIntPointer returns nil for a null number.
+func optionalIntPointer(value types.Int64) *int {
+	if value.IsNull() {
+		return nil
+	}
+
+	converted := int(value.ValueInt64())
+
+	return &converted
+}
+
+// optionalUint32Pointer returns nil for a null number.
+func optionalUint32Pointer(value types.Int64) *uint32 {
+	if value.IsNull() {
+		return nil
+	}
+
+	converted := uint32(value.ValueInt64())
+
+	return &converted
+}
+
+// intPointerValue returns a null number for a nil pointer.
+func intPointerValue[T int | uint32](value *T) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*value))
+}
+
+// durationValue returns the duration as a Go duration string, keeping the
+// prior string when it denotes the same duration, such as 1m for 60s.
+func durationValue(prior types.String, duration time.Duration) types.String {
+	if duration == 0 {
+		return types.StringNull()
+	}
+
+	if priorDuration, err := time.ParseDuration(prior.ValueString()); err == nil && priorDuration == duration {
+		return prior
+	}
+
+	return types.StringValue(duration.String())
+}
+
+// parseOptionalDuration parses a Go duration string, a null string being a zero duration.
+func parseOptionalDuration(attribute string, value types.String) (time.Duration, error) {
+	if value.IsNull() {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", attribute, value.ValueString(), err)
+	}
+
+	return duration, nil
+}
+
+// upstreamConfig returns the override described by data.
+func (data ConsulServiceUpstreamOverrideResourceModel) upstreamConfig() (*api.UpstreamConfig, error) {
+	override := &api.UpstreamConfig{
+		Name:             data.Upstream.ValueString(),
+		Peer:             data.Peer.ValueString(),
+		Protocol:         data.Protocol.ValueString(),
+		ConnectTimeoutMs: int(data.ConnectTimeoutMs.ValueInt64()),
+	}
+
+	if data.Limits != nil {
+		override.Limits = &api.UpstreamLimits{
+			MaxConnections:        optionalIntPointer(data.Limits.MaxConnections),
+			MaxPendingRequests:    optionalIntPointer(data.Limits.MaxPendingRequests),
+			MaxConcurrentRequests: optionalIntPointer(data.Limits.MaxConcurrentRequests),
+		}
+	}
+
+	if data.PassiveHealthCheck != nil {
+		interval, err := parseOptionalDuration("passive_health_check.interval", data.PassiveHealthCheck.Interval)
+
+		if err != nil {
+			return nil, err
+		}
+
+		baseEjectionTime, err := parseOptionalDuration("passive_health_check.base_ejection_time", data.PassiveHealthCheck.BaseEjectionTime)
+
+		if err != nil {
+			return nil, err
+		}
+
+		override.PassiveHealthCheck = &api.PassiveHealthCheck{
+			Interval:                interval,
+			MaxFailures:             uint32(data.PassiveHealthCheck.MaxFailures.ValueInt64()),
+			EnforcingConsecutive5xx: optionalUint32Pointer(data.PassiveHealthCheck.EnforcingConsecutive5xx),
+			MaxEjectionPercent:      optionalUint32Pointer(data.PassiveHealthCheck.MaxEjectionPercent),
+		}
+
+		if !data.PassiveHealthCheck.BaseEjectionTime.IsNull() {
+			override.PassiveHealthCheck.BaseEjectionTime = &baseEjectionTime
+		}
+	}
+
+	return override, nil
+}
+
+// readUpstreamConfig updates data with the override read from consul.
+func (data *ConsulServiceUpstreamOverrideResourceModel) readUpstreamConfig(override *api.UpstreamConfig) {
+	data.Protocol = optionalString(override.Protocol)
+	data.ConnectTimeoutMs = optionalInt64(override.ConnectTimeoutMs)
+
+	data.Limits = nil
+
+	if override.Limits != nil {
+		data.Limits = &ConsulUpstreamLimitsModel{
+			MaxConnections:        intPointerValue(override.Limits.MaxConnections),
+			MaxPendingRequests:    intPointerValue(override.Limits.MaxPendingRequests),
+			MaxConcurrentRequests: intPointerValue(override.Limits.MaxConcurrentRequests),
+		}
+	}
+
+	prior := data.PassiveHealthCheck
+
+	if prior == nil {
+		prior = &ConsulPassiveHealthCheckModel{}
+	}
+
+	data.PassiveHealthCheck = nil
+
+	if override.PassiveHealthCheck != nil {
+		var baseEjectionTime time.Duration
+
+		if override.PassiveHealthCheck.BaseEjectionTime != nil {
+			baseEjectionTime = *override.PassiveHealthCheck.BaseEjectionTime
+		}
+
+		data.PassiveHealthCheck = &ConsulPassiveHealthCheckModel{
+			Interval:                durationValue(prior.Interval, override.PassiveHealthCheck.Interval),
+			MaxFailures:             optionalInt64(int(override.PassiveHealthCheck.MaxFailures)),
+			EnforcingConsecutive5xx: intPointerValue(override.PassiveHealthCheck.EnforcingConsecutive5xx),
+			MaxEjectionPercent:      intPointerValue(override.PassiveHealthCheck.MaxEjectionPercent),
+			BaseEjectionTime:        durationValue(prior.BaseEjectionTime, baseEjectionTime),
+		}
+	}
+}
+
+func (data ConsulServiceUpstreamOverrideResourceModel) id() string {
+	if !data.Peer.IsNull() {
+		return fmt.Sprintf("%s_%s_%s", data.Service.ValueString(), data.Upstream.ValueString(), data.Peer.ValueString())
+	}
+
+	return fmt.Sprintf("%s_%s", data.Service.ValueString(), data.Upstream.ValueString())
+}
+
+// writeUpstreamOverride sets the override described by data in the
+// service-defaults entry. On create, an existing override of the upstream is
+// refused rather than replaced, as it belongs to another owner.
+func (r *ConsulServiceUpstreamOverrideResource) writeUpstreamOverride(ctx context.Context, client *api.Client, data ConsulServiceUpstreamOverrideResourceModel, create bool) error {
+	override, err := data.upstreamConfig()
+
+	if err != nil {
+		return err
+	}
+
+	return updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceDefaults, data.Service.ValueString(), newServiceDefaults(data.Service.ValueString()), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+		if create && findUpstreamOverride(configEntry, override.Name, override.Peer) != -1 {
+			return fmt.Errorf("an override of the upstream %s already exists", override.Name)
+		}
+
+		setUpstreamOverride(configEntry, override)
+		return nil
+	})
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulServiceUpstreamOverrideResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = r.writeUpstreamOverride(ctx, client, data, true)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service defaults, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service upstream override")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulServiceUpstreamOverrideResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceConfigEntry](client, api.ServiceDefaults, data.Service.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service defaults, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	i := findUpstreamOverride(configEntry, data.Upstream.ValueString(), data.Peer.ValueString())
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.readUpstreamConfig(configEntry.UpstreamConfig.Overrides[i])
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulServiceUpstreamOverrideResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	// The upstream and the peer require a replacement, so the override is updated in place
+	err = r.writeUpstreamOverride(ctx, client, data, false)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service defaults, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "service upstream override")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulServiceUpstreamOverrideResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.ServiceDefaults, data.Service.ValueString(), newServiceDefaults(data.Service.ValueString()), serviceDefaultsIsEmpty, func(configEntry *api.ServiceConfigEntry) error {
+		removeUpstreamOverride(configEntry, data.Upstream.ValueString(), data.Peer.ValueString())
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write service defaults, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulServiceUpstreamOverrideResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromID(ctx, req, resp, []string{"service", "upstream"}, []string{"service", "upstream", "peer"})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulServiceUpstreamOverrideResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_service_upstream_override" "test" {
+	service            = "invalid-service"
+	upstream           = "invalid-upstream"
+	connect_timeout_ms = 1000
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_service_upstream_override.test", "connect_timeout_ms", "1000"),
+					resource.TestCheckResourceAttr("utils_consul_service_upstream_override.test", "id", "invalid-service_invalid-upstream"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_service_upstream_override" "test" {
+	service  = "invalid-service"
+	upstream = "invalid-upstream"
+
+	passive_health_check = {
+		interval     = "10s"
+		max_failures = 5
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("utils_consul_service_upstream_override.test", "connect_timeout_ms"),
+					resource.TestCheckResourceAttr("utils_consul_service_upstream_override.test", "passive_health_check.interval", "10s"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_service_upstream_override.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestUpstreamOverrideRoundTrip(t *testing.T) {
+	client, _ := newTestConfigEntryClient(t)
+
+	data := ConsulServiceUpstreamOverrideResourceModel{
+		Service:          types.StringValue("web"),
+		Upstream:         types.StringValue("db"),
+		Peer:             types.StringValue("other-cluster"),
+		Protocol:         types.StringValue("tcp"),
+		ConnectTimeoutMs: types.Int64Value(2000),
+		Limits: &ConsulUpstreamLimitsModel{
+			MaxConnections: types.Int64Value(100),
+		},
+		PassiveHealthCheck: &ConsulPassiveHealthCheckModel{
+			Interval:           types.StringValue("1m"),
+			MaxFailures:        types.Int64Value(3),
+			MaxEjectionPercent: types.Int64Value(50),
+			BaseEjectionTime:   types.StringValue("30s"),
+		},
+	}
+
+	r := &ConsulServiceUpstreamOverrideResource{providerData: newUtilsProviderData(nil, testRetryPolicy, ConsulClusterModel{}, nil)}
+
+	if err := r.writeUpstreamOverride(context.Background(), client, data, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// The override of another owner is only replaced on update
+	if err := r.writeUpstreamOverride(context.Background(), client, data, true); err == nil {
+		t.Error("expected the creation of an existing override to fail")
+	}
+
+	if err := r.writeUpstreamOverride(context.Background(), client, data, false); err != nil {
+		t.Fatal(err)
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceConfigEntry](client, api.ServiceDefaults, "web")
+
+	if err != nil || !found {
+		t.Fatalf("expected the entry to be written, got %v", err)
+	}
+
+	i := findUpstreamOverride(configEntry, "db", "other-cluster")
+
+	if i == -1 {
+		t.Fatalf("expected the override to be written, got %+v", configEntry.UpstreamConfig)
+	}
+
+	read := ConsulServiceUpstreamOverrideResourceModel{
+		Service:            data.Service,
+		Upstream:           data.Upstream,
+		Peer:               data.Peer,
+		PassiveHealthCheck: &ConsulPassiveHealthCheckModel{Interval: types.StringValue("60s")},
+	}
+
+	read.readUpstreamConfig(configEntry.UpstreamConfig.Overrides[i])
+
+	// The prior 60s is kept rather than the 1m written by another configuration
+	data.PassiveHealthCheck.Interval = types.StringValue("60s")
+
+	if !reflect.DeepEqual(read, data) {
+		t.Errorf("expected the override to be read as written, got %+v and %+v", read, data)
+	}
+}
+
+func TestConsulServiceUpstreamOverrideResourceImportState(t *testing.T) {
+	r := &ConsulServiceUpstreamOverrideResource{}
+
+	testImportState(t, r, "web_db_other-cluster", map[string]attr.Value{
+		"service":  types.StringValue("web"),
+		"upstream": types.StringValue("db"),
+		"peer":     types.StringValue("other-cluster"),
+		"id":       types.StringValue("web_db_other-cluster"),
+	})
+
+	testImportState(t, r, "web_db", map[string]attr.Value{
+		"service":  types.StringValue("web"),
+		"upstream": types.StringValue("db"),
+		"peer":     types.StringNull(),
+	})
+
+	testImportState(t, r, "web", nil)
+}
@@ -6,69 +6,97 @@ package provider
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	api "github.com/hashicorp/consul/api"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var singleIntentionMutexes map[string]*sync.Mutex
-var singleIntentionMutexesLock sync.Mutex
+func newServiceIntentions(serviceName string) func() *api.ServiceIntentionsConfigEntry {
+	return func() *api.ServiceIntentionsConfigEntry {
+		return &api.ServiceIntentionsConfigEntry{
+			Kind: api.ServiceIntentions,
+			Name: serviceName,
+		}
+	}
+}
 
-func getMutexForSingleIntention(id string) *sync.Mutex {
-	singleIntentionMutexesLock.Lock()
-	defer singleIntentionMutexesLock.Unlock()
+func serviceIntentionsIsEmpty(configEntry *api.ServiceIntentionsConfigEntry) bool {
+	return len(configEntry.Sources) == 0
+}
 
-	if singleIntentionMutexes == nil {
-		singleIntentionMutexes = make(map[string]*sync.Mutex)
-	}
+// readServiceIntentions returns the intentions of the service, which are
+// empty when the entry does not exist. The other errors are returned, so that
+// a denied or failed read is never mistaken for an absence of intentions.
+func readServiceIntentions(client *api.Client, serviceName string) (*api.ServiceIntentionsConfigEntry, error) {
+	configEntry, found, err := readTypedConfigEntry[*api.ServiceIntentionsConfigEntry](client, api.ServiceIntentions, serviceName)
 
-	if _, ok := singleIntentionMutexes[id]; !ok {
-		singleIntentionMutexes[id] = &sync.Mutex{}
+	if err != nil {
+		return nil, err
 	}
 
-	mutexToHangOn := singleIntentionMutexes[id]
+	if !found {
+		return newServiceIntentions(serviceName)(), nil
+	}
 
-	return mutexToHangOn
+	return configEntry, nil
 }
 
-func readServiceIntentions(client *api.Client, serviceName string) *api.ServiceIntentionsConfigEntry {
-	configEntry, _, err := client.ConfigEntries().Get("service-intentions", serviceName, nil)
+// updateServiceIntentions applies update to the intentions of the service,
+// trying again when another writer modified them concurrently.
+func updateServiceIntentions(ctx context.Context, client *api.Client, retryPolicy RetryPolicy, serviceName string, update func(configEntry *api.ServiceIntentionsConfigEntry)) error {
+	return updateConfigEntryFragment(ctx, client, retryPolicy, api.ServiceIntentions, serviceName, newServiceIntentions(serviceName), serviceIntentionsIsEmpty, func(configEntry *api.ServiceIntentionsConfigEntry) error {
+		update(configEntry)
+		return nil
+	})
+}
 
-	if err != nil {
-		return &api.ServiceIntentionsConfigEntry{
-			Kind: "service-intentions",
-			Name: serviceName,
+// findSourceIntention returns the index of the intention from the service of
+// the given peer, the empty peer meaning the local cluster, or -1.
+func findSourceIntention(configEntry *api.ServiceIntentionsConfigEntry, sourceService, sourcePeer string) int {
+	for i, source := range configEntry.Sources {
+		if source.Name == sourceService && source.Peer == sourcePeer {
+			return i
 		}
 	}
 
-	return configEntry.(*api.ServiceIntentionsConfigEntry)
+	return -1
 }
 
-func writeServiceIntentions(client *api.Client, configEntry *api.ServiceIntentionsConfigEntry) error {
-	var err error
-
-	if len(configEntry.Sources) == 0 {
-		_, err = client.ConfigEntries().Delete("service-intentions", configEntry.Name, nil)
-	} else {
-		_, _, err = client.ConfigEntries().Set(configEntry, nil)
+// addSourceIntention allows the traffic from the service of the given peer,
+// unless an intention from it is already present.
+func addSourceIntention(configEntry *api.ServiceIntentionsConfigEntry, sourceService, sourcePeer string) {
+	if findSourceIntention(configEntry, sourceService, sourcePeer) != -1 {
+		return
 	}
 
-	return err
+	configEntry.Sources = append(configEntry.Sources, &api.SourceIntention{
+		Name:       sourceService,
+		Peer:       sourcePeer,
+		Action:     api.IntentionActionAllow,
+		Precedence: 9,
+		Type:       api.IntentionSourceConsul,
+	})
+}
+
+// removeSourceIntention removes the intention from the service of the given peer.
+func removeSourceIntention(configEntry *api.ServiceIntentionsConfigEntry, sourceService, sourcePeer string) {
+	if i := findSourceIntention(configEntry, sourceService, sourcePeer); i != -1 {
+		configEntry.Sources = append(configEntry.Sources[:i], configEntry.Sources[i+1:]...)
+	}
 }
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ConsulSingleIntentionResource{}
 var _ resource.ResourceWithImportState = &ConsulSingleIntentionResource{}
-
-// Allows for modification of exported-service only once at a time
+var _ resource.ResourceWithValidateConfig = &ConsulSingleIntentionResource{}
 
 func NewConsulSingleIntentionResource() resource.Resource {
 	return &ConsulSingleIntentionResource{}
@@ -76,14 +104,18 @@ func NewConsulSingleIntentionResource() resource.Resource {
 
 // ConsulSingleIntentionResource defines the resource implementation.
 type ConsulSingleIntentionResource struct {
-	client *api.Client
+	providerData *UtilsProviderData
 }
 
 // ConsulSingleIntentionResourceModel describes the resource data model.
 type ConsulSingleIntentionResourceModel struct {
+	Cluster            types.String `tfsdk:"cluster"`
 	DestinationService types.String `tfsdk:"destination_service"`
 	SourceService      types.String `tfsdk:"source_service"`
 	SourcePeer         types.String `tfsdk:"source_peer"`
+	ValidatePeer       types.Bool   `tfsdk:"validate_peer"`
+	WaitForActive      types.Bool   `tfsdk:"wait_for_active"`
+	WaitTimeout        types.String `tfsdk:"wait_for_active_timeout"`
 	Id                 types.String `tfsdk:"id"`
 }
 
@@ -97,6 +129,13 @@ func (r *ConsulSingleIntentionResource) Schema(ctx context.Context, req resource
 		MarkdownDescription: "Consul exported service resource",
 
 		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"destination_service": schema.StringAttribute{
 				MarkdownDescription: "The name of the destination service",
 				Required:            true,
@@ -118,6 +157,24 @@ func (r *ConsulSingleIntentionResource) Schema(ctx context.Context, req resource
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"validate_peer": schema.BoolAttribute{
+				MarkdownDescription: "Whether to check that `source_peer` exists before allowing its traffic. Ignored when `source_peer` is not set",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for `source_peer` to be `ACTIVE` before allowing its traffic. Implies `validate_peer`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_active_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for `source_peer` to become `ACTIVE`, as a Go duration string. Defaults to `5m`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultPeeringWaitTimeout),
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Exported peer identifier",
@@ -129,26 +186,28 @@ func (r *ConsulSingleIntentionResource) Schema(ctx context.Context, req resource
 	}
 }
 
+func (r *ConsulSingleIntentionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateWaitTimeout(ctx, req.Config, &resp.Diagnostics)
+}
+
 func (r *ConsulSingleIntentionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	createClient := req.ProviderData.(func(diagnostics *diag.Diagnostics) (*api.Client, error))
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
 
-	client, err := createClient(&resp.Diagnostics)
-
-	if err != nil {
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
 }
 
 func (r *ConsulSingleIntentionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -161,42 +220,32 @@ func (r *ConsulSingleIntentionResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	singleIntentionMutex := getMutexForSingleIntention(data.DestinationService.ValueString())
-
-	singleIntentionMutex.Lock()
-	defer singleIntentionMutex.Unlock()
-
-	serviceIntentionsConfigEntry := readServiceIntentions(r.client, data.DestinationService.ValueString())
-
-	if data.SourcePeer.IsNull() {
-		serviceIntentionsConfigEntry.Sources = append(serviceIntentionsConfigEntry.Sources, &api.SourceIntention{
-			Name:       data.SourceService.ValueString(),
-			Action:     api.IntentionActionAllow,
-			Precedence: 9,
-			Type:       api.IntentionSourceConsul,
-		})
-	} else {
-		serviceIntentionsConfigEntry.Sources = append(serviceIntentionsConfigEntry.Sources, &api.SourceIntention{
-			Name:       data.SourceService.ValueString(),
-			Peer:       data.SourcePeer.ValueString(),
-			Action:     api.IntentionActionAllow,
-			Precedence: 9,
-			Type:       api.IntentionSourceConsul,
-		})
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	if !data.SourcePeer.IsNull() {
+		err := checkPeering(ctx, client, data.SourcePeer.ValueString(), data.ValidatePeer, data.WaitForActive, data.WaitTimeout)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Peering Error", fmt.Sprintf("Unable to allow traffic from source peer, got error: %s", err))
+			return
+		}
 	}
 
-	err := writeServiceIntentions(r.client, serviceIntentionsConfigEntry)
+	err = updateServiceIntentions(ctx, client, r.providerData.RetryPolicy, data.DestinationService.ValueString(), func(serviceIntentionsConfigEntry *api.ServiceIntentionsConfigEntry) {
+		addSourceIntention(serviceIntentionsConfigEntry, data.SourceService.ValueString(), data.SourcePeer.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write services intentions, got error: %s", err))
 		return
 	}
 
-	if !data.SourcePeer.IsNull() {
-		data.Id = types.StringValue(fmt.Sprintf("%s_%s_%s", data.DestinationService.ValueString(), data.SourceService.ValueString(), data.SourcePeer.ValueString()))
-	} else {
-		data.Id = types.StringValue(fmt.Sprintf("%s_%s", data.DestinationService.ValueString(), data.SourceService.ValueString()))
-	}
+	data.Id = types.StringValue(intentionID(data.DestinationService.ValueString(), data.SourceService.ValueString(), data.SourcePeer))
 
 	tflog.Debug(ctx, "exported service")
 
@@ -213,22 +262,24 @@ func (r *ConsulSingleIntentionResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	serviceIntentionsConfigEntry := readServiceIntentions(r.client, data.DestinationService.ValueString())
-
-	for _, source := range serviceIntentionsConfigEntry.Sources {
-		if data.SourcePeer.IsNull() {
-			if source.Name == data.SourceService.ValueString() {
-				data.Id = types.StringValue(fmt.Sprintf("%s_%s", data.DestinationService.ValueString(), data.SourceService.ValueString()))
-				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-				return
-			}
-		} else {
-			if source.Name == data.SourceService.ValueString() && source.Peer == data.SourcePeer.ValueString() {
-				data.Id = types.StringValue(fmt.Sprintf("%s_%s_%s", data.DestinationService.ValueString(), data.SourceService.ValueString(), data.SourcePeer.ValueString()))
-				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-				return
-			}
-		}
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	serviceIntentionsConfigEntry, err := readServiceIntentions(client, data.DestinationService.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read services intentions, got error: %s", err))
+		return
+	}
+
+	if findSourceIntention(serviceIntentionsConfigEntry, data.SourceService.ValueString(), data.SourcePeer.ValueString()) != -1 {
+		data.Id = types.StringValue(intentionID(data.DestinationService.ValueString(), data.SourceService.ValueString(), data.SourcePeer))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
 	resp.State.RemoveResource(ctx)
@@ -248,62 +299,33 @@ func (r *ConsulSingleIntentionResource) Update(ctx context.Context, req resource
 		return
 	}
 
-	singleIntentionMutex := getMutexForSingleIntention(data.DestinationService.ValueString())
-
-	singleIntentionMutex.Lock()
-	defer singleIntentionMutex.Unlock()
-
-	serviceIntentionsConfigEntry := readServiceIntentions(r.client, data.DestinationService.ValueString())
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
 
-	sourceToRemove := -1
-
-	for i, source := range serviceIntentionsConfigEntry.Sources {
-		if oldData.SourcePeer.IsNull() {
-			if source.Name == oldData.SourceService.ValueString() {
-				sourceToRemove = i
-				break
-			}
-		} else {
-			if source.Name == oldData.SourceService.ValueString() && source.Peer == oldData.SourcePeer.ValueString() {
-				sourceToRemove = i
-				break
-			}
-		}
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
 	}
 
-	if sourceToRemove != -1 {
-		serviceIntentionsConfigEntry.Sources = append(serviceIntentionsConfigEntry.Sources[:sourceToRemove], serviceIntentionsConfigEntry.Sources[sourceToRemove+1:]...)
-	}
+	if !data.SourcePeer.IsNull() {
+		err := checkPeering(ctx, client, data.SourcePeer.ValueString(), data.ValidatePeer, data.WaitForActive, data.WaitTimeout)
 
-	if data.SourcePeer.IsNull() {
-		serviceIntentionsConfigEntry.Sources = append(serviceIntentionsConfigEntry.Sources, &api.SourceIntention{
-			Name:       data.SourceService.ValueString(),
-			Action:     api.IntentionActionAllow,
-			Precedence: 9,
-			Type:       api.IntentionSourceConsul,
-		})
-	} else {
-		serviceIntentionsConfigEntry.Sources = append(serviceIntentionsConfigEntry.Sources, &api.SourceIntention{
-			Name:       data.SourceService.ValueString(),
-			Peer:       data.SourcePeer.ValueString(),
-			Action:     api.IntentionActionAllow,
-			Precedence: 9,
-			Type:       api.IntentionSourceConsul,
-		})
+		if err != nil {
+			resp.Diagnostics.AddError("Peering Error", fmt.Sprintf("Unable to allow traffic from source peer, got error: %s", err))
+			return
+		}
 	}
 
-	err := writeServiceIntentions(r.client, serviceIntentionsConfigEntry)
+	err = updateServiceIntentions(ctx, client, r.providerData.RetryPolicy, data.DestinationService.ValueString(), func(serviceIntentionsConfigEntry *api.ServiceIntentionsConfigEntry) {
+		removeSourceIntention(serviceIntentionsConfigEntry, oldData.SourceService.ValueString(), oldData.SourcePeer.ValueString())
+		addSourceIntention(serviceIntentionsConfigEntry, data.SourceService.ValueString(), data.SourcePeer.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write services intentions, got error: %s", err))
 		return
 	}
 
-	if !data.SourcePeer.IsNull() {
-		data.Id = types.StringValue(fmt.Sprintf("%s_%s_%s", data.DestinationService.ValueString(), data.SourceService.ValueString(), data.SourcePeer.ValueString()))
-	} else {
-		data.Id = types.StringValue(fmt.Sprintf("%s_%s", data.DestinationService.ValueString(), data.SourceService.ValueString()))
-	}
+	data.Id = types.StringValue(intentionID(data.DestinationService.ValueString(), data.SourceService.ValueString(), data.SourcePeer))
 
 	tflog.Debug(ctx, "exported service")
 
@@ -320,34 +342,16 @@ func (r *ConsulSingleIntentionResource) Delete(ctx context.Context, req resource
 		return
 	}
 
-	singleIntentionMutex := getMutexForSingleIntention(data.DestinationService.ValueString())
-
-	singleIntentionMutex.Lock()
-	defer singleIntentionMutex.Unlock()
-
-	serviceIntentionsConfigEntry := readServiceIntentions(r.client, data.DestinationService.ValueString())
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
 
-	sourceToRemove := -1
-
-	for i, source := range serviceIntentionsConfigEntry.Sources {
-		if data.SourcePeer.IsNull() {
-			if source.Name == data.SourceService.ValueString() {
-				sourceToRemove = i
-				break
-			}
-		} else {
-			if source.Name == data.SourceService.ValueString() && source.Peer == data.SourcePeer.ValueString() {
-				sourceToRemove = i
-				break
-			}
-		}
-	}
-
-	if sourceToRemove != -1 {
-		serviceIntentionsConfigEntry.Sources = append(serviceIntentionsConfigEntry.Sources[:sourceToRemove], serviceIntentionsConfigEntry.Sources[sourceToRemove+1:]...)
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
 	}
 
-	err := writeServiceIntentions(r.client, serviceIntentionsConfigEntry)
+	err = updateServiceIntentions(ctx, client, r.providerData.RetryPolicy, data.DestinationService.ValueString(), func(serviceIntentionsConfigEntry *api.ServiceIntentionsConfigEntry) {
+		removeSourceIntention(serviceIntentionsConfigEntry, data.SourceService.ValueString(), data.SourcePeer.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write exported services, got error: %s", err))
@@ -358,5 +362,20 @@ func (r *ConsulSingleIntentionResource) Delete(ctx context.Context, req resource
 }
 
 func (r *ConsulSingleIntentionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parsed, err := parseIntentionID(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_service"), parsed.Destination)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_service"), parsed.Source)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_peer"), parsed.Peer)...)
+
+	// The peer checks are not stored in consul, the defaults are used until the next apply
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("validate_peer"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_active"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_active_timeout"), defaultPeeringWaitTimeout)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
@@ -4,9 +4,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -25,11 +32,11 @@ func TestAccConsulSingleIntentionResourceWithoutPeer(t *testing.T) {
 				),
 			},
 			// ImportState testing
-			// {
-			// 	ResourceName:      "utils_consul_single_intention.test",
-			// 	ImportState:       true,
-			// 	ImportStateVerify: true,
-			// },
+			{
+				ResourceName:      "utils_consul_single_intention.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccConsulSingleIntentionResourceConfigWithoutPeer("two"),
@@ -68,11 +75,11 @@ func TestAccConsulSingleIntentionResource(t *testing.T) {
 				),
 			},
 			// ImportState testing
-			// {
-			// 	ResourceName:      "utils_consul_single_intention.test",
-			// 	ImportState:       true,
-			// 	ImportStateVerify: true,
-			// },
+			{
+				ResourceName:      "utils_consul_single_intention.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccConsulSingleIntentionResourceConfig("two"),
@@ -95,3 +102,94 @@ resource "utils_consul_single_intention" "test" {
 }
 `, configurableAttribute)
 }
+
+func TestAddSourceIntention(t *testing.T) {
+	configEntry := &api.ServiceIntentionsConfigEntry{Name: "web"}
+
+	// Adding an intention again, as when retrying a write whose response was lost, keeps a single one
+	for i := 0; i < 2; i++ {
+		addSourceIntention(configEntry, "api", "")
+		addSourceIntention(configEntry, "api", "peer")
+	}
+
+	if len(configEntry.Sources) != 2 {
+		t.Fatalf("expected 2 intentions, got %d", len(configEntry.Sources))
+	}
+
+	if configEntry.Sources[1].Peer != "peer" || findSourceIntention(configEntry, "api", "other") != -1 {
+		t.Errorf("expected the intentions to be told apart by peer, got %+v", configEntry.Sources)
+	}
+}
+
+func TestUpdateServiceIntentions(t *testing.T) {
+	client, store := newTestConfigEntryClient(t)
+
+	update := func(update func(configEntry *api.ServiceIntentionsConfigEntry)) {
+		t.Helper()
+
+		if err := updateServiceIntentions(context.Background(), client, testRetryPolicy, "web", update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	update(func(configEntry *api.ServiceIntentionsConfigEntry) { addSourceIntention(configEntry, "api", "") })
+	update(func(configEntry *api.ServiceIntentionsConfigEntry) { removeSourceIntention(configEntry, "api", "peer") })
+
+	if entry := store.entry(api.ServiceIntentions, "web"); entry == nil {
+		t.Fatal("expected the intention from another peer to be kept")
+	}
+
+	update(func(configEntry *api.ServiceIntentionsConfigEntry) { removeSourceIntention(configEntry, "api", "") })
+
+	if entry := store.entry(api.ServiceIntentions, "web"); entry != nil {
+		t.Errorf("expected the entry to be deleted with its last intention, got %+v", entry)
+	}
+}
+
+func TestReadServiceIntentionsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(server.URL, "http://")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readServiceIntentions(client, "web"); err == nil {
+		t.Error("expected a denied read to fail rather than return no intentions")
+	}
+
+	err = updateServiceIntentions(context.Background(), client, testRetryPolicy, "web", func(configEntry *api.ServiceIntentionsConfigEntry) {
+		removeSourceIntention(configEntry, "api", "")
+	})
+
+	if err == nil {
+		t.Error("expected the removal to fail when the intentions cannot be read")
+	}
+}
+
+func TestConsulSingleIntentionResourceImportState(t *testing.T) {
+	r := &ConsulSingleIntentionResource{}
+
+	testImportState(t, r, "web_api", map[string]attr.Value{
+		"destination_service":     types.StringValue("web"),
+		"source_service":          types.StringValue("api"),
+		"source_peer":             types.StringNull(),
+		"validate_peer":           types.BoolValue(false),
+		"wait_for_active":         types.BoolValue(false),
+		"wait_for_active_timeout": types.StringValue(defaultPeeringWaitTimeout),
+		"id":                      types.StringValue("web_api"),
+	})
+
+	testImportState(t, r, "web_api_other-cluster", map[string]attr.Value{
+		"source_service": types.StringValue("api"),
+		"source_peer":    types.StringValue("other-cluster"),
+	})
+
+	for _, id := range []string{"web", "web_api_other-cluster_extra", "_api"} {
+		testImportState(t, r, id, nil)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsulTerminatingGatewayServiceResource{}
+var _ resource.ResourceWithImportState = &ConsulTerminatingGatewayServiceResource{}
+
+func NewConsulTerminatingGatewayServiceResource() resource.Resource {
+	return &ConsulTerminatingGatewayServiceResource{}
+}
+
+// ConsulTerminatingGatewayServiceResource defines the resource implementation.
+type ConsulTerminatingGatewayServiceResource struct {
+	providerData *UtilsProviderData
+}
+
+// ConsulTerminatingGatewayServiceResourceModel describes the resource data model.
+type ConsulTerminatingGatewayServiceResourceModel struct {
+	Cluster                types.String `tfsdk:"cluster"`
+	Gateway                types.String `tfsdk:"gateway"`
+	Service                types.String `tfsdk:"service"`
+	Namespace              types.String `tfsdk:"namespace"`
+	CAFile                 types.String `tfsdk:"ca_file"`
+	CertFile               types.String `tfsdk:"cert_file"`
+	KeyFile                types.String `tfsdk:"key_file"`
+	SNI                    types.String `tfsdk:"sni"`
+	DisableAutoHostRewrite types.Bool   `tfsdk:"disable_auto_host_rewrite"`
+	Id                     types.String `tfsdk:"id"`
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consul_terminating_gateway_service"
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Links a single external service to a `terminating-gateway` config entry, so that each team can reach its own external service through a shared gateway. Creating the resource fails when the service is already linked.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the `cluster` block of the provider to use. Defaults to the cluster of the top-level provider attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				MarkdownDescription: "The name of the terminating gateway",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The name of the service linked to the gateway, or `*` for every service of the namespace",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The namespace of the service",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_file": schema.StringAttribute{
+				MarkdownDescription: "The path, on the gateway, of the CA file used to verify the certificate of the service",
+				Optional:            true,
+			},
+			"cert_file": schema.StringAttribute{
+				MarkdownDescription: "The path, on the gateway, of the client certificate presented to the service",
+				Optional:            true,
+			},
+			"key_file": schema.StringAttribute{
+				MarkdownDescription: "The path, on the gateway, of the private key of the client certificate",
+				Optional:            true,
+			},
+			"sni": schema.StringAttribute{
+				MarkdownDescription: "The server name indicated to the service during the TLS handshake",
+				Optional:            true,
+			},
+			"disable_auto_host_rewrite": schema.BoolAttribute{
+				MarkdownDescription: "Whether the gateway keeps the host header of the requests instead of rewriting it to the address of the service",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Terminating gateway service identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// linkedService returns the service of data as listed by the gateway.
+func (data ConsulTerminatingGatewayServiceResourceModel) linkedService() api.LinkedService {
+	return api.LinkedService{
+		Name:                   data.Service.ValueString(),
+		Namespace:              data.Namespace.ValueString(),
+		CAFile:                 data.CAFile.ValueString(),
+		CertFile:               data.CertFile.ValueString(),
+		KeyFile:                data.KeyFile.ValueString(),
+		SNI:                    data.SNI.ValueString(),
+		DisableAutoHostRewrite: data.DisableAutoHostRewrite.ValueBool(),
+	}
+}
+
+func (data ConsulTerminatingGatewayServiceResourceModel) addTo(configEntry *api.TerminatingGatewayConfigEntry) error {
+	return addLinkedService(configEntry, data.linkedService(), false)
+}
+
+func (data ConsulTerminatingGatewayServiceResourceModel) updateIn(configEntry *api.TerminatingGatewayConfigEntry) error {
+	return addLinkedService(configEntry, data.linkedService(), true)
+}
+
+func (data ConsulTerminatingGatewayServiceResourceModel) removeFrom(configEntry *api.TerminatingGatewayConfigEntry) error {
+	removeLinkedService(configEntry, data.linkedService())
+	return nil
+}
+
+func (data ConsulTerminatingGatewayServiceResourceModel) id() string {
+	if data.Namespace.IsNull() {
+		return fmt.Sprintf("%s_%s", data.Gateway.ValueString(), data.Service.ValueString())
+	}
+
+	return fmt.Sprintf("%s_%s_%s", data.Gateway.ValueString(), data.Service.ValueString(), data.Namespace.ValueString())
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConsulTerminatingGatewayServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.TerminatingGateway, data.Gateway.ValueString(), newTerminatingGateway(data.Gateway.ValueString()), terminatingGatewayIsEmpty, data.addTo)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write terminating gateway, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "terminating gateway service")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConsulTerminatingGatewayServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	configEntry, found, err := readTypedConfigEntry[*api.TerminatingGatewayConfigEntry](client, api.TerminatingGateway, data.Gateway.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read terminating gateway, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	i := findLinkedService(configEntry, data.linkedService())
+
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	service := configEntry.Services[i]
+
+	data.CAFile = optionalString(service.CAFile)
+	data.CertFile = optionalString(service.CertFile)
+	data.KeyFile = optionalString(service.KeyFile)
+	data.SNI = optionalString(service.SNI)
+	data.DisableAutoHostRewrite = optionalBool(data.DisableAutoHostRewrite, service.DisableAutoHostRewrite)
+	data.Id = types.StringValue(data.id())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConsulTerminatingGatewayServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.TerminatingGateway, data.Gateway.ValueString(), newTerminatingGateway(data.Gateway.ValueString()), terminatingGatewayIsEmpty, data.updateIn)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write terminating gateway, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.id())
+
+	tflog.Debug(ctx, "terminating gateway service")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConsulTerminatingGatewayServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.providerData.Client(data.Cluster.ValueString(), &resp.Diagnostics)
+
+	// The reason of the failure has already been added to the diagnostics
+	if err != nil {
+		return
+	}
+
+	err = updateConfigEntryFragment(ctx, client, r.providerData.RetryPolicy, api.TerminatingGateway, data.Gateway.ValueString(), newTerminatingGateway(data.Gateway.ValueString()), terminatingGatewayIsEmpty, data.removeFrom)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to write terminating gateway, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ConsulTerminatingGatewayServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateFromID(ctx, req, resp, []string{"gateway", "service"}, []string{"gateway", "service", "namespace"})
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	api "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccConsulTerminatingGatewayServiceResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utils_consul_terminating_gateway_service" "test" {
+	gateway = "terminating"
+	service = "billing"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_terminating_gateway_service.test", "id", "terminating_billing"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `
+resource "utils_consul_terminating_gateway_service" "test" {
+	gateway = "terminating"
+	service = "billing"
+	ca_file = "/etc/ssl/billing-ca.pem"
+	sni     = "billing.example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utils_consul_terminating_gateway_service.test", "sni", "billing.example.com"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "utils_consul_terminating_gateway_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing
+		},
+	})
+}
+
+func TestAddLinkedService(t *testing.T) {
+	configEntry := newTerminatingGateway("terminating")()
+
+	if err := addLinkedService(configEntry, api.LinkedService{Name: "billing"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The service of another owner is only replaced on update
+	if err := addLinkedService(configEntry, api.LinkedService{Name: "billing", SNI: "billing.example.com"}, false); err == nil {
+		t.Error("expected an error when linking a service already linked")
+	}
+
+	if err := addLinkedService(configEntry, api.LinkedService{Name: "billing", SNI: "billing.example.com"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(configEntry.Services) != 1 || configEntry.Services[0].SNI != "billing.example.com" {
+		t.Errorf("expected the linked service to be replaced, got %+v", configEntry.Services)
+	}
+}
+
+func TestConsulTerminatingGatewayServiceResourceImportState(t *testing.T) {
+	r := &ConsulTerminatingGatewayServiceResource{}
+
+	testImportState(t, r, "terminating_billing_finance", map[string]attr.Value{
+		"gateway":   types.StringValue("terminating"),
+		"service":   types.StringValue("billing"),
+		"namespace": types.StringValue("finance"),
+		"id":        types.StringValue("terminating_billing_finance"),
+	})
+
+	testImportState(t, r, "terminating_billing", map[string]attr.Value{
+		"namespace": types.StringNull(),
+	})
+
+	testImportState(t, r, "terminating", nil)
+}
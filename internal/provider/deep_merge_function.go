@@ -0,0 +1,372 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The strategies merging the lists of the objects given to deep_merge.
+const (
+	replaceListStrategy = "replace"
+	appendListStrategy  = "append"
+	unionListStrategy   = "union"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DeepMergeFunction{}
+
+func NewDeepMergeFunction() function.Function {
+	return &DeepMergeFunction{}
+}
+
+// DeepMergeFunction defines the function implementation.
+type DeepMergeFunction struct{}
+
+// listStrategy is how deep_merge merges two lists.
+type listStrategy struct {
+	Strategy string
+	// Key is the attribute identifying the objects of the lists merged by
+	// the union strategy, the lists being merged as sets when empty.
+	Key string
+}
+
+// parseListStrategy parses a list strategy, such as union:name.
+func parseListStrategy(strategy string) (listStrategy, error) {
+	name, key, hasKey := strings.Cut(strategy, ":")
+
+	switch {
+	case hasKey && name == unionListStrategy && key != "":
+		return listStrategy{Strategy: name, Key: key}, nil
+	case hasKey:
+	case name == replaceListStrategy, name == appendListStrategy, name == unionListStrategy:
+		return listStrategy{Strategy: name}, nil
+	}
+
+	return listStrategy{}, fmt.Errorf("invalid list strategy %q, expected replace, append, union or union:<key>", strategy)
+}
+
+func (f *DeepMergeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "deep_merge"
+}
+
+func (f *DeepMergeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merges objects recursively",
+		MarkdownDescription: "Merges the objects from left to right, the attributes of the later objects replacing the attributes of the earlier ones, " +
+			"except for the objects and the maps which are merged recursively and the lists and the tuples which are merged according to `list_strategy`:\n\n" +
+			"- `replace`: the later list replaces the earlier one.\n" +
+			"- `append`: the elements of the later list are appended to the earlier one.\n" +
+			"- `union`: the elements of the later list which are not in the earlier one are appended to it.\n" +
+			"- `union:<key>`: the objects of the later list are merged recursively with the objects of the earlier one which have the same `<key>` attribute, and appended to it otherwise, such as `union:name` for the routes of a service router.\n\n" +
+			"The `null` objects and attributes are ignored, so that conditional attributes can be left unset. The maps are returned as objects, and the lists and the sets as tuples.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "list_strategy",
+				MarkdownDescription: "How the lists are merged, either `replace`, `append`, `union` or `union:<key>`",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "objects",
+			MarkdownDescription: "The objects to merge",
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+// toMergeValue returns the Go value of a Terraform value, objects and maps
+// being returned as map[string]any and collections as []any. The other
+// values are returned as is, and the null values as nil, except for the
+// null elements of the collections which keep their type.
+func toMergeValue(value attr.Value) any {
+	if value == nil || value.IsNull() {
+		return nil
+	}
+
+	var elements []attr.Value
+
+	switch value := value.(type) {
+	case types.Dynamic:
+		return toMergeValue(value.UnderlyingValue())
+	case types.Object:
+		return toMergeObject(value.Attributes())
+	case types.Map:
+		return toMergeObject(value.Elements())
+	case types.List:
+		elements = value.Elements()
+	case types.Set:
+		elements = value.Elements()
+	case types.Tuple:
+		elements = value.Elements()
+	default:
+		return value
+	}
+
+	list := make([]any, 0, len(elements))
+
+	for _, element := range elements {
+		if element.IsNull() {
+			list = append(list, element)
+		} else {
+			list = append(list, toMergeValue(element))
+		}
+	}
+
+	return list
+}
+
+func toMergeObject(attributes map[string]attr.Value) map[string]any {
+	object := make(map[string]any, len(attributes))
+
+	for name, attribute := range attributes {
+		// The null attributes are ignored
+		if value := toMergeValue(attribute); value != nil {
+			object[name] = value
+		}
+	}
+
+	return object
+}
+
+// fromMergeValue returns the Terraform value of a value returned by toMergeValue.
+func fromMergeValue(value any) (attr.Value, error) {
+	switch value := value.(type) {
+	case map[string]any:
+		attributeTypes := make(map[string]attr.Type, len(value))
+		attributes := make(map[string]attr.Value, len(value))
+
+		for name, attribute := range value {
+			converted, err := fromMergeValue(attribute)
+
+			if err != nil {
+				return nil, err
+			}
+
+			attributeTypes[name] = converted.Type(context.Background())
+			attributes[name] = converted
+		}
+
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build object: %v", diags)
+		}
+
+		return object, nil
+	case []any:
+		elementTypes := make([]attr.Type, 0, len(value))
+		elements := make([]attr.Value, 0, len(value))
+
+		for _, element := range value {
+			converted, err := fromMergeValue(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			elementTypes = append(elementTypes, converted.Type(context.Background()))
+			elements = append(elements, converted)
+		}
+
+		tuple, diags := types.TupleValue(elementTypes, elements)
+
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build tuple: %v", diags)
+		}
+
+		return tuple, nil
+	case attr.Value:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unexpected value %v", value)
+}
+
+// mergeValuesEqual tells whether two values returned by toMergeValue are equal.
+func mergeValuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case map[string]any:
+		b, ok := b.(map[string]any)
+
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for name, attribute := range a {
+			if other, ok := b[name]; !ok || !mergeValuesEqual(attribute, other) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		b, ok := b.([]any)
+
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !mergeValuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	case attr.Value:
+		b, ok := b.(attr.Value)
+
+		return ok && a.Equal(b)
+	}
+
+	return false
+}
+
+// deepMerge merges patch into target, which are values returned by toMergeValue.
+func deepMerge(target, patch any, strategy listStrategy) any {
+	switch patch := patch.(type) {
+	case nil:
+		return target
+	case map[string]any:
+		targetObject, ok := target.(map[string]any)
+
+		if !ok {
+			return patch
+		}
+
+		merged := make(map[string]any, len(targetObject)+len(patch))
+
+		for name, attribute := range targetObject {
+			merged[name] = attribute
+		}
+
+		for name, attribute := range patch {
+			merged[name] = deepMerge(merged[name], attribute, strategy)
+		}
+
+		return merged
+	case []any:
+		targetList, ok := target.([]any)
+
+		if !ok {
+			return patch
+		}
+
+		return mergeLists(targetList, patch, strategy)
+	}
+
+	return patch
+}
+
+// mergeLists merges the patch list into the target list according to strategy.
+func mergeLists(target, patch []any, strategy listStrategy) []any {
+	switch strategy.Strategy {
+	case appendListStrategy:
+		return append(append([]any(nil), target...), patch...)
+	case unionListStrategy:
+		merged := append([]any(nil), target...)
+
+		for _, element := range patch {
+			i := findMergeElement(merged, element, strategy.Key)
+
+			switch {
+			case i == -1:
+				merged = append(merged, element)
+			case strategy.Key != "":
+				merged[i] = deepMerge(merged[i], element, strategy)
+			}
+		}
+
+		return merged
+	}
+
+	return patch
+}
+
+// findMergeElement returns the index of the element of list equal to
+// element, or of the object having the same key attribute when key is set,
+// or -1.
+func findMergeElement(list []any, element any, key string) int {
+	if key != "" {
+		object, ok := element.(map[string]any)
+
+		if !ok || object[key] == nil {
+			return -1
+		}
+
+		for i, candidate := range list {
+			if candidateObject, ok := candidate.(map[string]any); ok && mergeValuesEqual(candidateObject[key], object[key]) {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	for i, candidate := range list {
+		if mergeValuesEqual(candidate, element) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (f *DeepMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var strategyName string
+	var objects types.Tuple
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &strategyName, &objects))
+
+	if resp.Error != nil {
+		return
+	}
+
+	strategy, err := parseListStrategy(strategyName)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var merged any
+
+	for i, object := range objects.Elements() {
+		value := toMergeValue(object)
+
+		switch value := value.(type) {
+		case []any:
+			resp.Error = function.NewArgumentFuncError(int64(i+1), "expected an object or a map, got a list")
+			return
+		case attr.Value:
+			resp.Error = function.NewArgumentFuncError(int64(i+1), fmt.Sprintf("expected an object or a map, got %s", value.Type(ctx)))
+			return
+		}
+
+		merged = deepMerge(merged, value, strategy)
+	}
+
+	// Only null objects were given
+	if merged == nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.DynamicNull()))
+		return
+	}
+
+	result, err := fromMergeValue(merged)
+
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to merge the objects: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.DynamicValue(result)))
+}
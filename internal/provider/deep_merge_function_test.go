@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDeepMergeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+	merged = provider::utils::deep_merge(
+		"union:name",
+		{ meta = { team = "platform" }, routes = [{ name = "api", retries = 1 }] },
+		{ meta = { tier = "web" }, routes = [{ name = "api", retries = 3 }, { name = "admin" }] },
+	)
+}
+
+output "test" {
+	value = jsonencode(local.merged)
+}
+`,
+				Check: resource.TestCheckOutput("test", `{"meta":{"team":"platform","tier":"web"},"routes":[{"name":"api","retries":3},{"name":"admin"}]}`),
+			},
+		},
+	})
+}
+
+// testObject returns the object value of attributes, inferring its type.
+func testObject(attributes map[string]attr.Value) types.Object {
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+
+	for name, attribute := range attributes {
+		attributeTypes[name] = attribute.Type(context.Background())
+	}
+
+	return types.ObjectValueMust(attributeTypes, attributes)
+}
+
+// testTuple returns the tuple value of elements, inferring its type.
+func testTuple(elements ...attr.Value) types.Tuple {
+	elementTypes := make([]attr.Type, 0, len(elements))
+
+	for _, element := range elements {
+		elementTypes = append(elementTypes, element.Type(context.Background()))
+	}
+
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+// testDeepMerge runs deep_merge with the strategy and the objects.
+func testDeepMerge(t *testing.T, strategy string, objects ...attr.Value) (attr.Value, bool) {
+	t.Helper()
+
+	arguments := make([]attr.Value, 0, len(objects))
+	argumentTypes := make([]attr.Type, 0, len(objects))
+
+	for _, object := range objects {
+		arguments = append(arguments, types.DynamicValue(object))
+		argumentTypes = append(argumentTypes, types.DynamicType)
+	}
+
+	result, funcErr := testRunFunction(t, NewDeepMergeFunction(), types.StringValue(strategy), types.TupleValueMust(argumentTypes, arguments))
+
+	if funcErr != nil {
+		return nil, false
+	}
+
+	if result.IsNull() {
+		return result, true
+	}
+
+	return result.(types.Dynamic).UnderlyingValue(), true
+}
+
+func TestDeepMergeFunction(t *testing.T) {
+	web := types.StringValue("web")
+	api := types.StringValue("api")
+
+	routes := func(retries int64) types.Object {
+		return testObject(map[string]attr.Value{"name": api, "retries": types.NumberValue(big.NewFloat(float64(retries)))})
+	}
+
+	base := testObject(map[string]attr.Value{
+		"name": web,
+		"meta": testObject(map[string]attr.Value{"team": types.StringValue("platform")}),
+		"tags": testTuple(types.StringValue("a"), types.StringValue("b")),
+		"routes": testTuple(
+			routes(1),
+			testObject(map[string]attr.Value{"name": types.StringValue("admin")}),
+		),
+	})
+
+	patch := testObject(map[string]attr.Value{
+		"meta": types.MapValueMust(types.StringType, map[string]attr.Value{"tier": types.StringValue("web")}),
+		"tags": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("b"), types.StringValue("c")}),
+		"routes": testTuple(
+			routes(3),
+			testObject(map[string]attr.Value{"name": types.StringValue("health")}),
+		),
+		"port": types.NumberValue(big.NewFloat(8080)),
+		"peer": types.StringNull(),
+	})
+
+	meta := testObject(map[string]attr.Value{"team": types.StringValue("platform"), "tier": types.StringValue("web")})
+
+	testCases := map[string]struct {
+		strategy string
+		expected attr.Value
+	}{
+		"replace": {
+			strategy: "replace",
+			expected: testObject(map[string]attr.Value{
+				"name":   web,
+				"meta":   meta,
+				"tags":   testTuple(types.StringValue("b"), types.StringValue("c")),
+				"routes": testTuple(routes(3), testObject(map[string]attr.Value{"name": types.StringValue("health")})),
+				"port":   types.NumberValue(big.NewFloat(8080)),
+			}),
+		},
+		"append": {
+			strategy: "append",
+			expected: testObject(map[string]attr.Value{
+				"name": web,
+				"meta": meta,
+				"tags": testTuple(types.StringValue("a"), types.StringValue("b"), types.StringValue("b"), types.StringValue("c")),
+				"routes": testTuple(
+					routes(1),
+					testObject(map[string]attr.Value{"name": types.StringValue("admin")}),
+					routes(3),
+					testObject(map[string]attr.Value{"name": types.StringValue("health")}),
+				),
+				"port": types.NumberValue(big.NewFloat(8080)),
+			}),
+		},
+		"union": {
+			strategy: "union",
+			expected: testObject(map[string]attr.Value{
+				"name": web,
+				"meta": meta,
+				"tags": testTuple(types.StringValue("a"), types.StringValue("b"), types.StringValue("c")),
+				"routes": testTuple(
+					routes(1),
+					testObject(map[string]attr.Value{"name": types.StringValue("admin")}),
+					routes(3),
+					testObject(map[string]attr.Value{"name": types.StringValue("health")}),
+				),
+				"port": types.NumberValue(big.NewFloat(8080)),
+			}),
+		},
+		"union by key": {
+			strategy: "union:name",
+			expected: testObject(map[string]attr.Value{
+				"name": web,
+				"meta": meta,
+				// The elements without the key are merged as a set
+				"tags": testTuple(types.StringValue("a"), types.StringValue("b"), types.StringValue("b"), types.StringValue("c")),
+				"routes": testTuple(
+					routes(3),
+					testObject(map[string]attr.Value{"name": types.StringValue("admin")}),
+					testObject(map[string]attr.Value{"name": types.StringValue("health")}),
+				),
+				"port": types.NumberValue(big.NewFloat(8080)),
+			}),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, ok := testDeepMerge(t, testCase.strategy, base, patch)
+
+			if !ok {
+				t.Fatal("unexpected error")
+			}
+
+			if !result.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, result)
+			}
+		})
+	}
+
+	t.Run("later values replace", func(t *testing.T) {
+		result, ok := testDeepMerge(t, "replace",
+			testObject(map[string]attr.Value{"a": testObject(map[string]attr.Value{"b": web}), "c": web}),
+			testObject(map[string]attr.Value{"a": api, "c": testTuple(web)}),
+			testObject(map[string]attr.Value{"a": testObject(map[string]attr.Value{"d": api})}),
+		)
+
+		expected := testObject(map[string]attr.Value{"a": testObject(map[string]attr.Value{"d": api}), "c": testTuple(web)})
+
+		if !ok || !result.Equal(expected) {
+			t.Errorf("expected %s, got %s", expected, result)
+		}
+	})
+
+	t.Run("null objects", func(t *testing.T) {
+		result, ok := testDeepMerge(t, "replace", types.ObjectNull(map[string]attr.Type{"a": types.StringType}), testObject(map[string]attr.Value{"a": web}), types.DynamicNull())
+
+		if expected := testObject(map[string]attr.Value{"a": web}); !ok || !result.Equal(expected) {
+			t.Errorf("expected %s, got %s", expected, result)
+		}
+
+		result, ok = testDeepMerge(t, "replace", types.DynamicNull())
+
+		if !ok || !result.IsNull() {
+			t.Errorf("expected a null result, got %s", result)
+		}
+
+		result, ok = testDeepMerge(t, "replace")
+
+		if !ok || !result.IsNull() {
+			t.Errorf("expected a null result without objects, got %s", result)
+		}
+	})
+
+	t.Run("null elements", func(t *testing.T) {
+		result, ok := testDeepMerge(t, "append",
+			testObject(map[string]attr.Value{"a": testTuple(web)}),
+			testObject(map[string]attr.Value{"a": testTuple(types.StringNull())}),
+		)
+
+		if expected := testObject(map[string]attr.Value{"a": testTuple(web, types.StringNull())}); !ok || !result.Equal(expected) {
+			t.Errorf("expected %s, got %s", expected, result)
+		}
+	})
+
+	for _, strategy := range []string{"", "merge", "union:", "append:name", "replace:name"} {
+		if result, ok := testDeepMerge(t, strategy, base); ok {
+			t.Errorf("expected an error for the list strategy %q, got %s", strategy, result)
+		}
+	}
+
+	for _, object := range []attr.Value{web, testTuple(web), types.ListValueMust(types.StringType, nil)} {
+		if result, ok := testDeepMerge(t, "replace", base, object); ok {
+			t.Errorf("expected an error for the argument %s, got %s", object, result)
+		}
+	}
+}
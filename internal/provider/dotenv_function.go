@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// dotenvKeyRegexp matches the names of the variables of a .env file.
+	dotenvKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+	// dotenvUnquotedRegexp matches the values written without quotes.
+	dotenvUnquotedRegexp = regexp.MustCompile(`^[A-Za-z0-9_./:,@+=%-]*$`)
+
+	// dotenvEscapes are the escape sequences of the double-quoted values.
+	dotenvEscapes = map[byte]string{'n': "\n", 'r': "\r", 't': "\t", '"': `"`, '\\': `\`, '$': "$"}
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DotenvDecodeFunction{}
+var _ function.Function = &DotenvEncodeFunction{}
+
+func NewDotenvDecodeFunction() function.Function {
+	return &DotenvDecodeFunction{}
+}
+
+func NewDotenvEncodeFunction() function.Function {
+	return &DotenvEncodeFunction{}
+}
+
+// DotenvDecodeFunction defines the function implementation.
+type DotenvDecodeFunction struct{}
+
+// DotenvEncodeFunction defines the function implementation.
+type DotenvEncodeFunction struct{}
+
+// decodeDotenvValue decodes the value of a variable, and returns the rest of
+// the line after it.
+func decodeDotenvValue(value string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+
+		if end == -1 {
+			return "", "", fmt.Errorf("unterminated single-quoted value")
+		}
+
+		return value[1 : end+1], value[end+2:], nil
+	case strings.HasPrefix(value, `"`):
+		var builder strings.Builder
+
+		for i := 1; i < len(value); i++ {
+			switch value[i] {
+			case '"':
+				return builder.String(), value[i+1:], nil
+			case '\\':
+				if i+1 < len(value) {
+					if unescaped, ok := dotenvEscapes[value[i+1]]; ok {
+						builder.WriteString(unescaped)
+						i++
+						continue
+					}
+				}
+			}
+
+			builder.WriteByte(value[i])
+		}
+
+		return "", "", fmt.Errorf("unterminated double-quoted value")
+	}
+
+	// The comments of the unquoted values are preceded by a space
+	if i := strings.Index(value, " #"); i != -1 {
+		value = value[:i]
+	}
+
+	return strings.TrimSpace(value), "", nil
+}
+
+// decodeDotenv decodes the variables of a .env file.
+func decodeDotenv(doc string) (map[string]string, error) {
+	variables := map[string]string{}
+
+	for i, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || !dotenvKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("line %d: expected a KEY=value pair, got %q", i+1, line)
+		}
+
+		value, rest, err := decodeDotenvValue(strings.TrimSpace(value))
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, fmt.Errorf("line %d: unexpected %q after the value of %s", i+1, rest, key)
+		}
+
+		variables[key] = value
+	}
+
+	return variables, nil
+}
+
+// encodeDotenv encodes the variables of a .env file, sorting them by name.
+func encodeDotenv(variables map[string]string) (string, error) {
+	keys := make([]string, 0, len(variables))
+
+	for key := range variables {
+		if !dotenvKeyRegexp.MatchString(key) {
+			return "", fmt.Errorf("invalid variable name %q", key)
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+	var builder strings.Builder
+
+	for _, key := range keys {
+		value := variables[key]
+
+		if !dotenvUnquotedRegexp.MatchString(value) {
+			value = `"` + escaper.Replace(value) + `"`
+		}
+
+		fmt.Fprintf(&builder, "%s=%s\n", key, value)
+	}
+
+	return builder.String(), nil
+}
+
+func (f *DotenvDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dotenv_decode"
+}
+
+func (f *DotenvDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decodes a .env file",
+		MarkdownDescription: "Decodes the `KEY=value` lines of a .env file into a map, ignoring the blank lines, the comments starting with `#` and the `export` prefixes. " +
+			"The single-quoted values are taken literally, the double-quoted values support the `\\n`, `\\r`, `\\t`, `\\\"`, `\\\\` and `\\$` escape sequences, " +
+			"and the unquoted values are trimmed and end at a ` #` comment. The variables are not interpolated.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "doc",
+				MarkdownDescription: "The content of the .env file to decode",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *DotenvDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var doc string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &doc))
+
+	if resp.Error != nil {
+		return
+	}
+
+	variables, err := decodeDotenv(doc)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid .env file: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, variables))
+}
+
+func (f *DotenvEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dotenv_encode"
+}
+
+func (f *DotenvEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes variables as a .env file",
+		MarkdownDescription: "Encodes a map of variables as the `KEY=value` lines of a .env file sorted by name. The values other than plain words, numbers, paths and URLs are double-quoted, with their backslashes, double quotes, dollar signs and control characters escaped, so that `dotenv_decode` returns them as is.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "variables",
+				MarkdownDescription: "The variables to encode",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DotenvEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var variables map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &variables))
+
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := encodeDotenv(variables)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDotenvFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::utils::dotenv_encode(provider::utils::dotenv_decode("PORT=8080\nNAME='my app'\n"))
+}
+`,
+				Check: resource.TestCheckOutput("test", "NAME=\"my app\"\nPORT=8080\n"),
+			},
+		},
+	})
+}
+
+func TestDotenvFunctions(t *testing.T) {
+	doc := `# Generated
+export PORT=8080
+URL = https://example.com/api?a=b # Comment
+LITERAL='$HOME \n'
+ESCAPED="line\nnext \"quoted\" \$HOME" # Comment
+EMPTY=
+HASH=a#b
+`
+
+	expected := map[string]string{
+		"PORT":    "8080",
+		"URL":     "https://example.com/api?a=b",
+		"LITERAL": `$HOME \n`,
+		"ESCAPED": "line\nnext \"quoted\" $HOME",
+		"EMPTY":   "",
+		"HASH":    "a#b",
+	}
+
+	result, funcErr := testRunFunction(t, NewDotenvDecodeFunction(), types.StringValue(doc))
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	var decoded map[string]string
+
+	if diags := result.(types.Map).ElementsAs(nil, &decoded, false); diags.HasError() || !reflect.DeepEqual(decoded, expected) {
+		t.Fatalf("expected %v, got %v, %v", expected, decoded, diags)
+	}
+
+	variables := make(map[string]attr.Value, len(expected))
+
+	for key, value := range expected {
+		variables[key] = types.StringValue(value)
+	}
+
+	result, funcErr = testRunFunction(t, NewDotenvEncodeFunction(), types.MapValueMust(types.StringType, variables))
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	encoded := `EMPTY=
+ESCAPED="line\nnext \"quoted\" \$HOME"
+HASH="a#b"
+LITERAL="\$HOME \\n"
+PORT=8080
+URL="https://example.com/api?a=b"
+`
+
+	if !result.Equal(types.StringValue(encoded)) {
+		t.Fatalf("expected\n%s\ngot\n%s", encoded, result.(types.String).ValueString())
+	}
+
+	// The encoded variables decode to the same values
+	if decoded, err := decodeDotenv(encoded); err != nil || !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected %v, got %v, %v", expected, decoded, err)
+	}
+
+	for _, doc := range []string{"PORT\n", "1PORT=8080\n", "NAME=\"web\n", "NAME='web\n", "NAME=\"web\" suffix\n"} {
+		if result, funcErr := testRunFunction(t, NewDotenvDecodeFunction(), types.StringValue(doc)); funcErr == nil {
+			t.Errorf("expected an error for %q, got %s", doc, result)
+		}
+	}
+
+	if encoded, err := encodeDotenv(map[string]string{"MY-VAR": "a"}); err == nil {
+		t.Errorf("expected an error for an invalid variable name, got %q", encoded)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The layouts of the local dates and times of TOML, which are decoded with
+// a location of the same name.
+var localTimeLayouts = map[string]string{
+	"datetime-local": "2006-01-02T15:04:05.999999999",
+	"date-local":     "2006-01-02",
+	"time-local":     "15:04:05.999999999",
+}
+
+// toNativeValue returns the Go value of a Terraform value for the encoders,
+// objects and maps being returned as map[string]any, collections as []any,
+// numbers as int64 when they are integers in range and float64 otherwise,
+// and null values as nil.
+func toNativeValue(value attr.Value) (any, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("unknown values cannot be encoded")
+	}
+
+	var elements []attr.Value
+
+	switch value := value.(type) {
+	case types.Dynamic:
+		return toNativeValue(value.UnderlyingValue())
+	case types.String:
+		return value.ValueString(), nil
+	case types.Bool:
+		return value.ValueBool(), nil
+	case types.Int64:
+		return value.ValueInt64(), nil
+	case types.Float64:
+		return value.ValueFloat64(), nil
+	case types.Number:
+		number := value.ValueBigFloat()
+
+		if number.IsInt() {
+			if integer, accuracy := number.Int64(); accuracy == big.Exact {
+				return integer, nil
+			}
+		}
+
+		float, _ := number.Float64()
+
+		return float, nil
+	case types.Object:
+		return toNativeObject(value.Attributes())
+	case types.Map:
+		return toNativeObject(value.Elements())
+	case types.List:
+		elements = value.Elements()
+	case types.Set:
+		elements = value.Elements()
+	case types.Tuple:
+		elements = value.Elements()
+	default:
+		return nil, fmt.Errorf("values of type %s cannot be encoded", value.Type(context.Background()))
+	}
+
+	list := make([]any, 0, len(elements))
+
+	for _, element := range elements {
+		native, err := toNativeValue(element)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, native)
+	}
+
+	return list, nil
+}
+
+func toNativeObject(attributes map[string]attr.Value) (map[string]any, error) {
+	object := make(map[string]any, len(attributes))
+
+	for name, attribute := range attributes {
+		native, err := toNativeValue(attribute)
+
+		if err != nil {
+			return nil, err
+		}
+
+		object[name] = native
+	}
+
+	return object, nil
+}
+
+// fromNativeValue returns the Terraform value of a decoded document, objects
+// being returned as objects and arrays as tuples.
+func fromNativeValue(value any) (attr.Value, error) {
+	switch value := value.(type) {
+	case string:
+		return types.StringValue(value), nil
+	case bool:
+		return types.BoolValue(value), nil
+	case int64:
+		return types.NumberValue(new(big.Float).SetInt64(value)), nil
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("the number %v cannot be represented", value)
+		}
+
+		return types.NumberValue(big.NewFloat(value)), nil
+	case time.Time:
+		if layout, ok := localTimeLayouts[value.Location().String()]; ok {
+			return types.StringValue(value.Format(layout)), nil
+		}
+
+		return types.StringValue(value.Format(time.RFC3339Nano)), nil
+	case map[string]any:
+		attributeTypes := make(map[string]attr.Type, len(value))
+		attributes := make(map[string]attr.Value, len(value))
+
+		for name, attribute := range value {
+			converted, err := fromNativeValue(attribute)
+
+			if err != nil {
+				return nil, err
+			}
+
+			attributeTypes[name] = converted.Type(context.Background())
+			attributes[name] = converted
+		}
+
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build object: %v", diags)
+		}
+
+		return object, nil
+	case []map[string]any:
+		list := make([]any, 0, len(value))
+
+		for _, element := range value {
+			list = append(list, element)
+		}
+
+		return fromNativeValue(list)
+	case []any:
+		elementTypes := make([]attr.Type, 0, len(value))
+		elements := make([]attr.Value, 0, len(value))
+
+		for _, element := range value {
+			converted, err := fromNativeValue(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			elementTypes = append(elementTypes, converted.Type(context.Background()))
+			elements = append(elements, converted)
+		}
+
+		tuple, diags := types.TupleValue(elementTypes, elements)
+
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build tuple: %v", diags)
+		}
+
+		return tuple, nil
+	}
+
+	return nil, fmt.Errorf("unexpected value %v of type %T", value, value)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// parseImportID splits the ID of an imported resource into its parts,
+// expecting the number of parts of one of formats, which list the attributes
+// the parts are made of. The parts cannot contain underscores for the ID to be parsed.
+func parseImportID(id string, formats ...[]string) ([]string, error) {
+	parts := strings.Split(id, "_")
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, invalidImportIDError(id, formats)
+		}
+	}
+
+	for _, format := range formats {
+		if len(format) == len(parts) {
+			return parts, nil
+		}
+	}
+
+	return nil, invalidImportIDError(id, formats)
+}
+
+func invalidImportIDError(id string, formats [][]string) error {
+	described := make([]string, 0, len(formats))
+
+	for _, format := range formats {
+		described = append(described, "<"+strings.Join(format, ">_<")+">")
+	}
+
+	return fmt.Errorf("invalid import ID %q, expected %s", id, strings.Join(described, " or "))
+}
+
+// importStateFromID imports a resource whose ID joins the string attributes
+// of one of formats with underscores, such as {"resolver", "name"}, setting
+// them in the imported state for the first read to find the resource.
+func importStateFromID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, formats ...[]string) {
+	parts, err := parseImportID(req.ID, formats...)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	for _, format := range formats {
+		if len(format) != len(parts) {
+			continue
+		}
+
+		for i, attribute := range format {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), parts[i])...)
+		}
+
+		break
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
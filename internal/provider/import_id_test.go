@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseImportID(t *testing.T) {
+	testCases := map[string]struct {
+		id       string
+		expected []string
+	}{
+		"short format": {
+			id:       "web_api",
+			expected: []string{"web", "api"},
+		},
+		"long format": {
+			id:       "web_api_peer",
+			expected: []string{"web", "api", "peer"},
+		},
+		"too many parts": {
+			id: "web_api_peer_other",
+		},
+		"too few parts": {
+			id: "web",
+		},
+		"empty part": {
+			id: "web__peer",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parts, err := parseImportID(testCase.id, []string{"service", "upstream"}, []string{"service", "upstream", "peer"})
+
+			if testCase.expected == nil {
+				if err == nil {
+					t.Errorf("expected an error, got %q", parts)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(parts, testCase.expected) {
+				t.Errorf("expected %q, got %q", testCase.expected, parts)
+			}
+		})
+	}
+}
+
+// testImportState imports id with r, checking the attributes of the imported
+// state, named with dots for nested ones, or that the import fails when
+// expected is nil.
+func testImportState(t *testing.T, r resource.ResourceWithImportState, id string, expected map[string]attr.Value) {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+
+	if expected == nil {
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("expected importing %q to fail", id)
+		}
+
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to import %q: %v", id, resp.Diagnostics)
+	}
+
+	for name, value := range expected {
+		names := strings.Split(name, ".")
+		attributePath := path.Root(names[0])
+
+		for _, nested := range names[1:] {
+			attributePath = attributePath.AtName(nested)
+		}
+
+		actual := reflect.New(reflect.TypeOf(value))
+
+		if diags := resp.State.GetAttribute(ctx, attributePath, actual.Interface()); diags.HasError() {
+			t.Fatalf("unable to get %s: %v", name, diags)
+		}
+
+		if actual := actual.Elem().Interface().(attr.Value); !actual.Equal(value) {
+			t.Errorf("importing %q: expected %s to be %s, got %s", id, name, value, actual)
+		}
+	}
+}
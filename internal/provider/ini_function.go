@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &INIDecodeFunction{}
+var _ function.Function = &INIEncodeFunction{}
+
+func NewINIDecodeFunction() function.Function {
+	return &INIDecodeFunction{}
+}
+
+func NewINIEncodeFunction() function.Function {
+	return &INIEncodeFunction{}
+}
+
+// INIDecodeFunction defines the function implementation.
+type INIDecodeFunction struct{}
+
+// INIEncodeFunction defines the function implementation.
+type INIEncodeFunction struct{}
+
+// iniSectionType is the type of a section of an INI document, mapping its
+// keys to their values.
+var iniSectionType = types.MapType{ElemType: types.StringType}
+
+// unquoteINIValue removes the quotes surrounding a value.
+func unquoteINIValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// decodeINI decodes the sections of an INI document.
+func decodeINI(doc string) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+	section := ""
+
+	for i, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "", strings.HasPrefix(line, ";"), strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header %q", i+1, line)
+			}
+
+			section = strings.TrimSpace(line[1 : len(line)-1])
+
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty section name", i+1)
+			}
+
+			if sections[section] == nil {
+				sections[section] = map[string]string{}
+			}
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected a key = value pair, got %q", i+1, line)
+		}
+
+		if sections[section] == nil {
+			sections[section] = map[string]string{}
+		}
+
+		sections[section][key] = unquoteINIValue(strings.TrimSpace(value))
+	}
+
+	return sections, nil
+}
+
+// encodeINIValue quotes a value when it would not be decoded as is.
+func encodeINIValue(value string) string {
+	if value != strings.TrimSpace(value) || value != unquoteINIValue(value) || strings.ContainsAny(value, ";#") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
+
+// encodeINI encodes the sections of an INI document, sorting the sections and their keys.
+func encodeINI(sections map[string]map[string]string) (string, error) {
+	var builder strings.Builder
+
+	names := make([]string, 0, len(sections))
+
+	for name := range sections {
+		names = append(names, name)
+	}
+
+	// The section named "" sorts first, so that its keys precede the first section header
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.ContainsAny(name, "[]\n") {
+			return "", fmt.Errorf("invalid section name %q", name)
+		}
+
+		if name != "" {
+			if builder.Len() > 0 {
+				builder.WriteString("\n")
+			}
+
+			fmt.Fprintf(&builder, "[%s]\n", name)
+		}
+
+		keys := make([]string, 0, len(sections[name]))
+
+		for key := range sections[name] {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value := sections[name][key]
+
+			if key == "" || key != strings.TrimSpace(key) || strings.ContainsAny(key, "=[;#\n") {
+				return "", fmt.Errorf("invalid key %q in section %q", key, name)
+			}
+
+			if strings.Contains(value, "\n") {
+				return "", fmt.Errorf("the value of the key %q in section %q spans several lines", key, name)
+			}
+
+			fmt.Fprintf(&builder, "%s = %s\n", key, encodeINIValue(value))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (f *INIDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ini_decode"
+}
+
+func (f *INIDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decodes an INI document",
+		MarkdownDescription: "Decodes an INI document into a map of its sections, each section being a map of its `key = value` pairs. The keys before the first section header are in the section named `\"\"`. The lines starting with `;` or `#` are comments, and the quotes surrounding a value are removed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "doc",
+				MarkdownDescription: "The INI document to decode",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: iniSectionType,
+		},
+	}
+}
+
+func (f *INIDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var doc string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &doc))
+
+	if resp.Error != nil {
+		return
+	}
+
+	sections, err := decodeINI(doc)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid INI document: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, sections))
+}
+
+func (f *INIEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ini_encode"
+}
+
+func (f *INIEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes sections as an INI document",
+		MarkdownDescription: "Encodes a map of sections, each section being a map of strings, as an INI document with its sections and their keys sorted. The keys of the section named `\"\"` are written before the first section header. The values are quoted when they have leading or trailing spaces, or contain `;` or `#`.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "sections",
+				MarkdownDescription: "The sections to encode",
+				ElementType:         iniSectionType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *INIEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var sections map[string]map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &sections))
+
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := encodeINI(sections)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
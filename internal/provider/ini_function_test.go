@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccINIFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::utils::ini_encode(provider::utils::ini_decode("[server]\nport = 8080\n"))
+}
+`,
+				Check: resource.TestCheckOutput("test", "[server]\nport = 8080\n"),
+			},
+		},
+	})
+}
+
+func TestINIFunctions(t *testing.T) {
+	doc := `; Generated
+name = web
+
+[server]
+port=8080
+host = " 0.0.0.0 "
+# Comment
+path = '/api;v1'
+
+[empty]
+`
+
+	expected := map[string]map[string]string{
+		"":       {"name": "web"},
+		"server": {"port": "8080", "host": " 0.0.0.0 ", "path": "/api;v1"},
+		"empty":  {},
+	}
+
+	sections, err := decodeINI(doc)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(sections, expected) {
+		t.Fatalf("expected %v, got %v", expected, sections)
+	}
+
+	encoded := `name = web
+
+[empty]
+
+[server]
+host = " 0.0.0.0 "
+path = "/api;v1"
+port = 8080
+`
+
+	result, funcErr := testRunFunction(t, NewINIEncodeFunction(), types.MapValueMust(iniSectionType, map[string]attr.Value{
+		"":       types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringValue("web")}),
+		"empty":  types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		"server": types.MapValueMust(types.StringType, map[string]attr.Value{"port": types.StringValue("8080"), "host": types.StringValue(" 0.0.0.0 "), "path": types.StringValue("/api;v1")}),
+	}))
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	if !result.Equal(types.StringValue(encoded)) {
+		t.Fatalf("expected\n%s\ngot\n%s", encoded, result.(types.String).ValueString())
+	}
+
+	// The encoded document decodes to the same sections
+	result, funcErr = testRunFunction(t, NewINIDecodeFunction(), types.StringValue(encoded))
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	var decoded map[string]map[string]string
+
+	if diags := result.(types.Map).ElementsAs(nil, &decoded, false); diags.HasError() || !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected %v, got %v, %v", expected, decoded, diags)
+	}
+
+	// The quotes of a value are kept when it is encoded
+	if encoded, err := encodeINI(map[string]map[string]string{"": {"a": `"quoted"`}}); err != nil || encoded != "a = \"\"quoted\"\"\n" {
+		t.Errorf("expected the quoted value to be quoted again, got %q, %v", encoded, err)
+	}
+
+	for _, doc := range []string{"[server\n", "[]\n", "name\n", "= value\n"} {
+		if result, funcErr := testRunFunction(t, NewINIDecodeFunction(), types.StringValue(doc)); funcErr == nil {
+			t.Errorf("expected an error for %q, got %s", doc, result)
+		}
+	}
+
+	for _, sections := range []map[string]map[string]string{
+		{"a]": {"b": "c"}},
+		{"": {"b=": "c"}},
+		{"": {"": "c"}},
+		{"": {"b": "c\nd"}},
+	} {
+		if encoded, err := encodeINI(sections); err == nil {
+			t.Errorf("expected an error for %v, got %q", sections, encoded)
+		}
+	}
+}
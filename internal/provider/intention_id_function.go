@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &IntentionIdFunction{}
+var _ function.Function = &ParseIntentionIdFunction{}
+
+func NewIntentionIdFunction() function.Function {
+	return &IntentionIdFunction{}
+}
+
+func NewParseIntentionIdFunction() function.Function {
+	return &ParseIntentionIdFunction{}
+}
+
+// IntentionIdFunction defines the function implementation.
+type IntentionIdFunction struct{}
+
+// ParseIntentionIdFunction defines the function implementation.
+type ParseIntentionIdFunction struct{}
+
+// IntentionIdModel describes the parts of the ID of a single intention.
+type IntentionIdModel struct {
+	Destination types.String `tfsdk:"destination"`
+	Source      types.String `tfsdk:"source"`
+	Peer        types.String `tfsdk:"peer"`
+}
+
+// intentionIdAttributeTypes are the attribute types of IntentionIdModel.
+var intentionIdAttributeTypes = map[string]attr.Type{
+	"destination": types.StringType,
+	"source":      types.StringType,
+	"peer":        types.StringType,
+}
+
+// intentionID returns the ID of the single intention from source to
+// destination, as set by the utils_consul_single_intention resource.
+func intentionID(destination, source string, peer types.String) string {
+	// An empty peer is a local source, as parse_intention_id returns it
+	if peer.ValueString() != "" {
+		return fmt.Sprintf("%s_%s_%s", destination, source, peer.ValueString())
+	}
+
+	return fmt.Sprintf("%s_%s", destination, source)
+}
+
+// parseIntentionID returns the parts of the ID of a single intention. The
+// service names cannot contain underscores for the ID to be parsed.
+func parseIntentionID(id string) (IntentionIdModel, error) {
+	parts := strings.Split(id, "_")
+
+	if (len(parts) != 2 && len(parts) != 3) || parts[0] == "" || parts[1] == "" {
+		return IntentionIdModel{}, fmt.Errorf("invalid intention ID %q, expected destination_source or destination_source_peer", id)
+	}
+
+	parsed := IntentionIdModel{
+		Destination: types.StringValue(parts[0]),
+		Source:      types.StringValue(parts[1]),
+		Peer:        types.StringNull(),
+	}
+
+	if len(parts) == 3 {
+		parsed.Peer = types.StringValue(parts[2])
+	}
+
+	return parsed, nil
+}
+
+func (f *IntentionIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "intention_id"
+}
+
+func (f *IntentionIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds the ID of a single intention",
+		MarkdownDescription: "Returns the ID of the `utils_consul_single_intention` resource allowing or denying the source service to reach the destination service, such as `web_api` or `web_api_other-cluster` for a source imported from a peer. The ID can be used in `import` blocks.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "destination",
+				MarkdownDescription: "The name of the destination service",
+			},
+			function.StringParameter{
+				Name:                "source",
+				MarkdownDescription: "The name of the source service",
+			},
+			function.StringParameter{
+				Name:                "peer",
+				MarkdownDescription: "The peer the source service is imported from, or `null` or an empty string for a local source service",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IntentionIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var destination, source string
+	var peer types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &destination, &source, &peer))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, intentionID(destination, source, peer)))
+}
+
+func (f *ParseIntentionIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_intention_id"
+}
+
+func (f *ParseIntentionIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parses the ID of a single intention",
+		MarkdownDescription: "Returns an object with the `destination`, `source` and `peer` attributes of the ID of a `utils_consul_single_intention` resource, `peer` being `null` for a local source service. This is the inverse of `intention_id`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The ID of the single intention",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: intentionIdAttributeTypes,
+		},
+	}
+}
+
+func (f *ParseIntentionIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseIntentionID(id)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, parsed))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccIntentionIdFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "id" {
+	value = provider::utils::intention_id("api", "web", "other-cluster")
+}
+
+output "source" {
+	value = provider::utils::parse_intention_id("api_web").source
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("id", "api_web_other-cluster"),
+					resource.TestCheckOutput("source", "web"),
+				),
+			},
+		},
+	})
+}
+
+func TestIntentionIdFunctions(t *testing.T) {
+	testCases := map[string]struct {
+		destination string
+		source      string
+		peer        types.String
+		expected    string
+		parsedPeer  types.String
+	}{
+		"local source":    {destination: "api", source: "web", peer: types.StringNull(), expected: "api_web", parsedPeer: types.StringNull()},
+		"imported source": {destination: "api", source: "web", peer: types.StringValue("other-cluster"), expected: "api_web_other-cluster", parsedPeer: types.StringValue("other-cluster")},
+		// An empty peer is a local source, whose ID parse_intention_id accepts
+		"empty peer": {destination: "api", source: "web", peer: types.StringValue(""), expected: "api_web", parsedPeer: types.StringNull()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testRunFunction(t, NewIntentionIdFunction(), types.StringValue(testCase.destination), types.StringValue(testCase.source), testCase.peer)
+
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+
+			if !result.Equal(types.StringValue(testCase.expected)) {
+				t.Fatalf("expected %q, got %s", testCase.expected, result)
+			}
+
+			// Parsing the ID returns the arguments it was built from, an empty peer being null
+			result, funcErr = testRunFunction(t, NewParseIntentionIdFunction(), types.StringValue(testCase.expected))
+
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+
+			expected := types.ObjectValueMust(intentionIdAttributeTypes, map[string]attr.Value{
+				"destination": types.StringValue(testCase.destination),
+				"source":      types.StringValue(testCase.source),
+				"peer":        testCase.parsedPeer,
+			})
+
+			if !result.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, result)
+			}
+		})
+	}
+
+	for _, id := range []string{"api", "api_", "_web", "api_web_peer_extra", ""} {
+		if result, funcErr := testRunFunction(t, NewParseIntentionIdFunction(), types.StringValue(id)); funcErr == nil {
+			t.Errorf("expected an error for the ID %q, got %s", id, result)
+		}
+	}
+}
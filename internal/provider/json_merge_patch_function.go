@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONMergePatchFunction{}
+
+func NewJSONMergePatchFunction() function.Function {
+	return &JSONMergePatchFunction{}
+}
+
+// JSONMergePatchFunction defines the function implementation.
+type JSONMergePatchFunction struct{}
+
+func (f *JSONMergePatchFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_merge_patch"
+}
+
+func (f *JSONMergePatchFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Applies a JSON Merge Patch to a JSON document",
+		MarkdownDescription: "Applies the JSON Merge Patch (RFC 7386) `patch` to the JSON document `doc`, and returns the patched document encoded in JSON with its object members sorted. The members of the patch replace the members of the document of the same name, objects being merged recursively, and the `null` members of the patch remove the members of the document.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "doc",
+				MarkdownDescription: "The JSON document to patch",
+			},
+			function.StringParameter{
+				Name:                "patch",
+				MarkdownDescription: "The JSON Merge Patch document",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JSONMergePatchFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var doc, patch string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &doc, &patch))
+
+	if resp.Error != nil {
+		return
+	}
+
+	decodedDoc, err := decodeJSON([]byte(doc))
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid JSON document: %s", err))
+		return
+	}
+
+	decodedPatch, err := decodeJSON([]byte(patch))
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid JSON Merge Patch: %s", err))
+		return
+	}
+
+	patched, _, err := applyJSONPatch(decodedDoc, mergePatchOperations(decodedDoc, decodedPatch))
+
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to apply the JSON Merge Patch: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(encodeJSONValue(patched))))
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccJSONMergePatchFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::utils::json_merge_patch(
+		jsonencode({ Kind = "service-defaults", Name = "web", Protocol = "tcp" }),
+		jsonencode({ Protocol = "http", Name = null }),
+	)
+}
+`,
+				Check: resource.TestCheckOutput("test", `{"Kind":"service-defaults","Protocol":"http"}`),
+			},
+		},
+	})
+}
+
+func TestJSONMergePatchFunction(t *testing.T) {
+	// The examples of the appendix A of RFC 7386
+	testCases := map[string]struct {
+		doc      string
+		patch    string
+		expected string
+	}{
+		"replace member":          {doc: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		"add member":              {doc: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		"remove member":           {doc: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		"remove one member":       {doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		"replace array":           {doc: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		"replace by array":        {doc: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		"merge nested":            {doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		"replace nested array":    {doc: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		"replace array document":  {doc: `["a","b"]`, patch: `["c","d"]`, expected: `["c","d"]`},
+		"replace by object":       {doc: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+		"replace by null":         {doc: `{"a":"foo"}`, patch: `null`, expected: `null`},
+		"replace by string":       {doc: `{"a":"foo"}`, patch: `"bar"`, expected: `"bar"`},
+		"keep null members":       {doc: `{"e":null}`, patch: `{"a":1}`, expected: `{"a":1,"e":null}`},
+		"replace array by object": {doc: `[1,2]`, patch: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+		"create nested":           {doc: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+		"keep numbers":            {doc: `{"a":1.50,"b":10000000000000000001}`, patch: `{}`, expected: `{"a":1.50,"b":10000000000000000001}`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testRunFunction(t, NewJSONMergePatchFunction(), types.StringValue(testCase.doc), types.StringValue(testCase.patch))
+
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+
+			if !result.Equal(types.StringValue(testCase.expected)) {
+				t.Errorf("expected %s, got %s", testCase.expected, result)
+			}
+		})
+	}
+
+	errorCases := map[string]struct {
+		doc      string
+		patch    string
+		argument int64
+	}{
+		"invalid doc":   {doc: `{"a":`, patch: `{}`, argument: 0},
+		"invalid patch": {doc: `{}`, patch: `{} {}`, argument: 1},
+	}
+
+	for name, errorCase := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testRunFunction(t, NewJSONMergePatchFunction(), types.StringValue(errorCase.doc), types.StringValue(errorCase.patch))
+
+			if funcErr == nil || funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != errorCase.argument {
+				t.Errorf("expected an error for the argument %d, got %s, %v", errorCase.argument, result, funcErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,622 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPatchOperation is an operation of a JSON Patch (RFC 6902) document.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// decodeJSON decodes a JSON document, keeping its numbers as written.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+
+	return value, nil
+}
+
+// encodeJSONValue encodes value, which must be a decoded JSON document.
+func encodeJSONValue(value any) json.RawMessage {
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
+
+// decodeJSONPatch decodes a JSON Patch document, checking its operations.
+func decodeJSONPatch(data []byte) ([]jsonPatchOperation, error) {
+	var operations []jsonPatchOperation
+
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return nil, fmt.Errorf("invalid JSON patch: %w", err)
+	}
+
+	for i, operation := range operations {
+		if _, err := parseJSONPointer(operation.Path); err != nil {
+			return nil, fmt.Errorf("invalid path of operation %d: %w", i, err)
+		}
+
+		switch operation.Op {
+		case "add", "replace", "test":
+			if operation.Value == nil {
+				return nil, fmt.Errorf("operation %d (%s) has no value", i, operation.Op)
+			}
+		case "move", "copy":
+			if _, err := parseJSONPointer(operation.From); err != nil {
+				return nil, fmt.Errorf("invalid from of operation %d: %w", i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("operation %d has unknown op %q", i, operation.Op)
+		}
+	}
+
+	return operations, nil
+}
+
+// parseJSONPointer returns the reference tokens of a JSON Pointer (RFC 6901).
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q does not start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// formatJSONPointer returns the JSON Pointer of the reference tokens.
+func formatJSONPointer(tokens []string) string {
+	var pointer strings.Builder
+
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+
+	return pointer.String()
+}
+
+// arrayIndex returns the index of an array designated by token, which may
+// designate the end of the array when allowEnd is set.
+func arrayIndex(array []any, token string, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return len(array), nil
+	}
+
+	index, err := strconv.Atoi(token)
+
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	if index > len(array) || (index == len(array) && !allowEnd) {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+
+	return index, nil
+}
+
+// jsonPointerGet returns the value designated by tokens in doc.
+func jsonPointerGet(doc any, tokens []string) (any, error) {
+	node := doc
+
+	for i, token := range tokens {
+		switch container := node.(type) {
+		case map[string]any:
+			child, ok := container[token]
+
+			if !ok {
+				return nil, fmt.Errorf("path %s does not exist", formatJSONPointer(tokens[:i+1]))
+			}
+
+			node = child
+		case []any:
+			index, err := arrayIndex(container, token, false)
+
+			if err != nil {
+				return nil, fmt.Errorf("path %s: %w", formatJSONPointer(tokens[:i+1]), err)
+			}
+
+			node = container[index]
+		default:
+			return nil, fmt.Errorf("path %s does not exist", formatJSONPointer(tokens[:i+1]))
+		}
+	}
+
+	return node, nil
+}
+
+// jsonPointerUpdate replaces the container of the last token of tokens with
+// the result of update, and returns the updated doc.
+func jsonPointerUpdate(doc any, tokens []string, update func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return update(doc, tokens[0])
+	}
+
+	child, err := jsonPointerGet(doc, tokens[:1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := jsonPointerUpdate(child, tokens[1:], update)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := doc.(type) {
+	case map[string]any:
+		container[tokens[0]] = updated
+	case []any:
+		index, _ := arrayIndex(container, tokens[0], false)
+		container[index] = updated
+	}
+
+	return doc, nil
+}
+
+func jsonPointerAdd(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return jsonPointerUpdate(doc, tokens, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			index, err := arrayIndex(container, token, true)
+
+			if err != nil {
+				return nil, err
+			}
+
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+
+			return container, nil
+		}
+
+		return nil, fmt.Errorf("cannot add %q to a value which is neither an object nor an array", token)
+	})
+}
+
+func jsonPointerRemove(doc any, tokens []string) (any, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	return jsonPointerUpdate(doc, tokens, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+
+			delete(container, token)
+
+			return container, nil
+		case []any:
+			index, err := arrayIndex(container, token, false)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return append(container[:index], container[index+1:]...), nil
+		}
+
+		return nil, fmt.Errorf("cannot remove %q from a value which is neither an object nor an array", token)
+	})
+}
+
+func jsonPointerReplace(doc any, tokens []string, value any) (any, error) {
+	if _, err := jsonPointerGet(doc, tokens); err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return jsonPointerUpdate(doc, tokens, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			container[token] = value
+		case []any:
+			index, _ := arrayIndex(container, token, false)
+			container[index] = value
+		}
+
+		return container, nil
+	})
+}
+
+// copyJSONValue returns a deep copy of a decoded JSON document.
+func copyJSONValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(value))
+
+		for key, child := range value {
+			copied[key] = copyJSONValue(child)
+		}
+
+		return copied
+	case []any:
+		copied := make([]any, len(value))
+
+		for i, child := range value {
+			copied[i] = copyJSONValue(child)
+		}
+
+		return copied
+	}
+
+	return value
+}
+
+// jsonEqual tells whether two decoded JSON documents are equal, comparing
+// numbers by value and objects regardless of the order of their members.
+func jsonEqual(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for key, child := range a {
+			other, ok := b[key]
+
+			if !ok || !jsonEqual(child, other) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		b, ok := b.([]any)
+
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+
+		if !ok {
+			return false
+		}
+
+		if a == b {
+			return true
+		}
+
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+
+		return errA == nil && errB == nil && x == y
+	}
+
+	return a == b
+}
+
+// applyJSONPatchOperation applies a single operation to doc, and returns the
+// operations undoing it, each change being preceded by a test of the value
+// it undoes.
+func applyJSONPatchOperation(doc any, operation jsonPatchOperation) (any, []jsonPatchOperation, error) {
+	tokens, err := parseJSONPointer(operation.Path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var value any
+
+	if operation.Value != nil {
+		if value, err = decodeJSON(operation.Value); err != nil {
+			return nil, nil, fmt.Errorf("invalid value: %w", err)
+		}
+	}
+
+	switch operation.Op {
+	case "add":
+		return addWithInverse(doc, tokens, value)
+	case "remove":
+		return removeWithInverse(doc, tokens)
+	case "replace":
+		previous, err := jsonPointerGet(doc, tokens)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		doc, err = jsonPointerReplace(doc, tokens, value)
+
+		return doc, []jsonPatchOperation{
+			{Op: "test", Path: operation.Path, Value: operation.Value},
+			{Op: "replace", Path: operation.Path, Value: encodeJSONValue(previous)},
+		}, err
+	case "move":
+		from, err := parseJSONPointer(operation.From)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(tokens) > len(from) && formatJSONPointer(tokens[:len(from)]) == operation.From {
+			return nil, nil, fmt.Errorf("cannot move %s into itself", operation.From)
+		}
+
+		moved, err := jsonPointerGet(doc, from)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		doc, removeInverse, err := removeWithInverse(doc, from)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		doc, addInverse, err := addWithInverse(doc, tokens, moved)
+
+		return doc, append(addInverse, removeInverse...), err
+	case "copy":
+		from, err := parseJSONPointer(operation.From)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		copied, err := jsonPointerGet(doc, from)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return addWithInverse(doc, tokens, copyJSONValue(copied))
+	case "test":
+		actual, err := jsonPointerGet(doc, tokens)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !jsonEqual(actual, value) {
+			return nil, nil, fmt.Errorf("test of %s failed: expected %s, got %s", operation.Path, operation.Value, encodeJSONValue(actual))
+		}
+
+		return doc, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown op %q", operation.Op)
+}
+
+func addWithInverse(doc any, tokens []string, value any) (any, []jsonPatchOperation, error) {
+	path := formatJSONPointer(tokens)
+	test := jsonPatchOperation{Op: "test", Path: path, Value: encodeJSONValue(value)}
+
+	if len(tokens) > 0 {
+		parent, err := jsonPointerGet(doc, tokens[:len(tokens)-1])
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// The index of the value inserted at the end of an array is only known now
+		if array, ok := parent.([]any); ok {
+			index, err := arrayIndex(array, tokens[len(tokens)-1], true)
+
+			if err != nil {
+				return nil, nil, fmt.Errorf("path %s: %w", path, err)
+			}
+
+			path = formatJSONPointer(append(append([]string(nil), tokens[:len(tokens)-1]...), strconv.Itoa(index)))
+			test.Path = path
+			doc, err = jsonPointerAdd(doc, tokens, value)
+
+			return doc, []jsonPatchOperation{test, {Op: "remove", Path: path}}, err
+		}
+	}
+
+	previous, err := jsonPointerGet(doc, tokens)
+	existed := err == nil
+
+	doc, err = jsonPointerAdd(doc, tokens, value)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if existed {
+		return doc, []jsonPatchOperation{test, {Op: "replace", Path: path, Value: encodeJSONValue(previous)}}, nil
+	}
+
+	return doc, []jsonPatchOperation{test, {Op: "remove", Path: path}}, nil
+}
+
+// removeWithInverse removes the value at tokens, and returns the add undoing
+// it, which undoJSONPatch skips when the member was set again since.
+func removeWithInverse(doc any, tokens []string) (any, []jsonPatchOperation, error) {
+	previous, err := jsonPointerGet(doc, tokens)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err = jsonPointerRemove(doc, tokens)
+
+	return doc, []jsonPatchOperation{{Op: "add", Path: formatJSONPointer(tokens), Value: encodeJSONValue(previous)}}, err
+}
+
+// applyJSONPatch applies operations to a copy of doc, and returns the
+// operations undoing them.
+func applyJSONPatch(doc any, operations []jsonPatchOperation) (any, []jsonPatchOperation, error) {
+	doc = copyJSONValue(doc)
+
+	var inverse []jsonPatchOperation
+
+	for i, operation := range operations {
+		var undo []jsonPatchOperation
+		var err error
+
+		doc, undo, err = applyJSONPatchOperation(doc, operation)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+
+		inverse = append(undo, inverse...)
+	}
+
+	return doc, inverse, nil
+}
+
+// jsonMemberExists tells whether the object member at path is set. The values
+// inserted in arrays do not overwrite others, so they are never reported.
+func jsonMemberExists(doc any, path string) bool {
+	tokens, err := parseJSONPointer(path)
+
+	if err != nil || len(tokens) == 0 {
+		return err == nil
+	}
+
+	parent, err := jsonPointerGet(doc, tokens[:len(tokens)-1])
+
+	if err != nil {
+		return false
+	}
+
+	object, ok := parent.(map[string]any)
+
+	if !ok {
+		return false
+	}
+
+	_, exists := object[tokens[len(tokens)-1]]
+
+	return exists
+}
+
+// undoJSONPatch applies the inverse operations returned by applyJSONPatch to
+// a copy of doc. A failed test skips the operation following it, and the add
+// undoing a remove is skipped when the member was set again, so that the
+// values modified by others since the patch was applied are kept, and the
+// operations which no longer apply are skipped.
+func undoJSONPatch(doc any, inverse []jsonPatchOperation) any {
+	doc = copyJSONValue(doc)
+
+	for i := 0; i < len(inverse); i++ {
+		// Only the inverse of a remove is an add
+		if inverse[i].Op == "add" && jsonMemberExists(doc, inverse[i].Path) {
+			continue
+		}
+
+		updated, _, err := applyJSONPatchOperation(doc, inverse[i])
+
+		if err != nil {
+			if inverse[i].Op == "test" {
+				i++
+			}
+
+			continue
+		}
+
+		doc = updated
+	}
+
+	return doc
+}
+
+// mergePatchOperations returns the JSON Patch operations applying the JSON
+// Merge Patch (RFC 7386) patch to doc.
+func mergePatchOperations(doc, patch any) []jsonPatchOperation {
+	return appendMergePatchOperations(nil, nil, doc, patch)
+}
+
+func appendMergePatchOperations(operations []jsonPatchOperation, tokens []string, target, patch any) []jsonPatchOperation {
+	patchObject, ok := patch.(map[string]any)
+
+	if !ok {
+		return append(operations, jsonPatchOperation{Op: "add", Path: formatJSONPointer(tokens), Value: encodeJSONValue(patch)})
+	}
+
+	targetObject, ok := target.(map[string]any)
+
+	if !ok {
+		// The target is replaced by an object holding the members of the patch
+		targetObject = make(map[string]any)
+		operations = append(operations, jsonPatchOperation{Op: "add", Path: formatJSONPointer(tokens), Value: json.RawMessage("{}")})
+	}
+
+	keys := make([]string, 0, len(patchObject))
+
+	for key := range patchObject {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := append(append([]string(nil), tokens...), key)
+		current, exists := targetObject[key]
+
+		switch {
+		case patchObject[key] == nil && exists:
+			operations = append(operations, jsonPatchOperation{Op: "remove", Path: formatJSONPointer(child)})
+		case patchObject[key] == nil:
+		default:
+			if !exists {
+				current = nil
+			}
+
+			operations = appendMergePatchOperations(operations, child, current, patchObject[key])
+		}
+	}
+
+	return operations
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONPatchFunction{}
+
+func NewJSONPatchFunction() function.Function {
+	return &JSONPatchFunction{}
+}
+
+// JSONPatchFunction defines the function implementation.
+type JSONPatchFunction struct{}
+
+func (f *JSONPatchFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_patch"
+}
+
+func (f *JSONPatchFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Applies a JSON Patch to a JSON document",
+		MarkdownDescription: "Applies the operations of the JSON Patch (RFC 6902) `ops` to the JSON document `doc`, and returns the patched document encoded in JSON with its object members sorted. The patch fails as a whole when one of its operations fails, such as a `test` operation or the removal of a missing member.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "doc",
+				MarkdownDescription: "The JSON document to patch",
+			},
+			function.StringParameter{
+				Name:                "ops",
+				MarkdownDescription: "The JSON array of the operations of the patch, such as `[{\"op\": \"add\", \"path\": \"/a\", \"value\": 1}]`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JSONPatchFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var doc, ops string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &doc, &ops))
+
+	if resp.Error != nil {
+		return
+	}
+
+	decodedDoc, err := decodeJSON([]byte(doc))
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid JSON document: %s", err))
+		return
+	}
+
+	operations, err := decodeJSONPatch([]byte(ops))
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid JSON Patch: %s", err))
+		return
+	}
+
+	patched, _, err := applyJSONPatch(decodedDoc, operations)
+
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to apply the JSON Patch: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(encodeJSONValue(patched))))
+}
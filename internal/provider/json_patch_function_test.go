@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccJSONPatchFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::utils::json_patch(
+		jsonencode({ Routes = [{ Destination = { Service = "web" } }] }),
+		jsonencode([{ op = "add", path = "/Routes/0/Destination/NumRetries", value = 3 }]),
+	)
+}
+`,
+				Check: resource.TestCheckOutput("test", `{"Routes":[{"Destination":{"NumRetries":3,"Service":"web"}}]}`),
+			},
+		},
+	})
+}
+
+func TestJSONPatchFunction(t *testing.T) {
+	// Examples of the appendix A of RFC 6902
+	testCases := map[string]struct {
+		doc      string
+		ops      string
+		expected string
+	}{
+		"add member":       {doc: `{"foo":"bar"}`, ops: `[{"op":"add","path":"/baz","value":"qux"}]`, expected: `{"baz":"qux","foo":"bar"}`},
+		"add element":      {doc: `{"foo":["bar","baz"]}`, ops: `[{"op":"add","path":"/foo/1","value":"qux"}]`, expected: `{"foo":["bar","qux","baz"]}`},
+		"append element":   {doc: `{"foo":["bar"]}`, ops: `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, expected: `{"foo":["bar",["abc","def"]]}`},
+		"remove member":    {doc: `{"baz":"qux","foo":"bar"}`, ops: `[{"op":"remove","path":"/baz"}]`, expected: `{"foo":"bar"}`},
+		"remove element":   {doc: `{"foo":["bar","qux","baz"]}`, ops: `[{"op":"remove","path":"/foo/1"}]`, expected: `{"foo":["bar","baz"]}`},
+		"replace member":   {doc: `{"baz":"qux","foo":"bar"}`, ops: `[{"op":"replace","path":"/baz","value":"boo"}]`, expected: `{"baz":"boo","foo":"bar"}`},
+		"move member":      {doc: `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, ops: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		"move element":     {doc: `{"foo":["all","grass","cows","eat"]}`, ops: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, expected: `{"foo":["all","cows","eat","grass"]}`},
+		"copy member":      {doc: `{"foo":{"bar":1}}`, ops: `[{"op":"copy","from":"/foo","path":"/baz"}]`, expected: `{"baz":{"bar":1},"foo":{"bar":1}}`},
+		"successful test":  {doc: `{"baz":"qux","foo":["a",2,"c"]}`, ops: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, expected: `{"baz":"qux","foo":["a",2,"c"]}`},
+		"escaped pointer":  {doc: `{"/":9,"~1":10}`, ops: `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, expected: `{"~1":10}`},
+		"replace document": {doc: `{"foo":"bar"}`, ops: `[{"op":"replace","path":"","value":[1]}]`, expected: `[1]`},
+		"no operation":     {doc: `{"foo":"bar"}`, ops: `[]`, expected: `{"foo":"bar"}`},
+		"sequential ops":   {doc: `{}`, ops: `[{"op":"add","path":"/a","value":{}},{"op":"add","path":"/a/b","value":1}]`, expected: `{"a":{"b":1}}`},
+		"numbers by value": {doc: `{"a":1.0}`, ops: `[{"op":"test","path":"/a","value":1}]`, expected: `{"a":1.0}`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testRunFunction(t, NewJSONPatchFunction(), types.StringValue(testCase.doc), types.StringValue(testCase.ops))
+
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+
+			if !result.Equal(types.StringValue(testCase.expected)) {
+				t.Errorf("expected %s, got %s", testCase.expected, result)
+			}
+		})
+	}
+
+	errorCases := map[string]struct {
+		doc string
+		ops string
+		// argument is the argument of the error, or -1 for the errors of the function
+		argument int64
+	}{
+		"invalid doc":       {doc: `{`, ops: `[]`, argument: 0},
+		"invalid ops":       {doc: `{}`, ops: `{"op":"add"}`, argument: 1},
+		"unknown operation": {doc: `{}`, ops: `[{"op":"merge","path":"/a"}]`, argument: 1},
+		"failed test":       {doc: `{"baz":"qux"}`, ops: `[{"op":"test","path":"/baz","value":"bar"}]`, argument: -1},
+		"missing member":    {doc: `{"foo":"bar"}`, ops: `[{"op":"add","path":"/baz/bat","value":"qux"}]`, argument: -1},
+		"out of bounds":     {doc: `{"foo":["bar"]}`, ops: `[{"op":"remove","path":"/foo/1"}]`, argument: -1},
+	}
+
+	for name, errorCase := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := testRunFunction(t, NewJSONPatchFunction(), types.StringValue(errorCase.doc), types.StringValue(errorCase.ops))
+
+			if funcErr == nil {
+				t.Fatalf("expected an error, got %s", result)
+			}
+
+			argument := int64(-1)
+
+			if funcErr.FunctionArgument != nil {
+				argument = *funcErr.FunctionArgument
+			}
+
+			if argument != errorCase.argument {
+				t.Errorf("expected an error for the argument %d, got %v", errorCase.argument, funcErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func mustDecodeJSON(t *testing.T, data string) any {
+	t.Helper()
+
+	value, err := decodeJSON([]byte(data))
+
+	if err != nil {
+		t.Fatalf("unable to decode %s: %s", data, err)
+	}
+
+	return value
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	// Examples of the appendix A of RFC 6902
+	cases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add object member", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{"add array element", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{"append array element", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{"remove object member", `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{"remove array element", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{"replace value", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{"move value", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`, `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{"move array element", `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"copy value", `{"foo": {"bar": 1}}`, `[{"op": "copy", "from": "/foo", "path": "/baz"}]`, `{"foo": {"bar": 1}, "baz": {"bar": 1}}`},
+		{"test value", `{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`, `{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"escaped path", `{"a/b": {"m~n": 1}}`, `[{"op": "replace", "path": "/a~1b/m~0n", "value": 2}]`, `{"a/b": {"m~n": 2}}`},
+		{"replace document", `{"foo": "bar"}`, `[{"op": "replace", "path": "", "value": ["baz"]}]`, `["baz"]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			operations, err := decodeJSONPatch([]byte(c.patch))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc := mustDecodeJSON(t, c.doc)
+			patched, inverse, err := applyJSONPatch(doc, operations)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !jsonEqual(patched, mustDecodeJSON(t, c.expected)) {
+				t.Errorf("expected %s, got %s", c.expected, encodeJSONValue(patched))
+			}
+
+			if !jsonEqual(doc, mustDecodeJSON(t, c.doc)) {
+				t.Errorf("expected the document not to be modified, got %s", encodeJSONValue(doc))
+			}
+
+			if undone := undoJSONPatch(patched, inverse); !jsonEqual(undone, doc) {
+				t.Errorf("expected the inverse to restore %s, got %s", c.doc, encodeJSONValue(undone))
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+	}{
+		{"missing parent", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`},
+		{"failed test", `{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`},
+		{"out of bounds", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": "qux"}]`},
+		{"missing member", `{"foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`},
+		{"move into itself", `{"foo": {"bar": 1}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			operations, err := decodeJSONPatch([]byte(c.patch))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := applyJSONPatch(mustDecodeJSON(t, c.doc), operations); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	for _, patch := range []string{`{"op": "add"}`, `[{"op": "add", "path": "/foo"}]`, `[{"op": "frobnicate", "path": "/foo"}]`, `[{"op": "remove", "path": "foo"}]`} {
+		if _, err := decodeJSONPatch([]byte(patch)); err == nil {
+			t.Errorf("expected %s to be invalid", patch)
+		}
+	}
+}
+
+func TestMergePatchOperations(t *testing.T) {
+	// Examples of the appendix A of RFC 7386
+	cases := []struct {
+		doc      string
+		patch    string
+		expected string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{`["a", "b"]`, `["c", "d"]`, `["c", "d"]`},
+		{`{"a": "b"}`, `["c"]`, `["c"]`},
+		{`{"a": "foo"}`, `null`, `null`},
+		{`{"e": null}`, `{"a": 1}`, `{"e": null, "a": 1}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	}
+
+	for _, c := range cases {
+		doc := mustDecodeJSON(t, c.doc)
+		patched, inverse, err := applyJSONPatch(doc, mergePatchOperations(doc, mustDecodeJSON(t, c.patch)))
+
+		if err != nil {
+			t.Errorf("unable to merge %s into %s: %s", c.patch, c.doc, err)
+			continue
+		}
+
+		if !jsonEqual(patched, mustDecodeJSON(t, c.expected)) {
+			t.Errorf("expected merging %s into %s to give %s, got %s", c.patch, c.doc, c.expected, encodeJSONValue(patched))
+		}
+
+		if undone := undoJSONPatch(patched, inverse); !jsonEqual(undone, doc) {
+			t.Errorf("expected the inverse of merging %s to restore %s, got %s", c.patch, c.doc, encodeJSONValue(undone))
+		}
+	}
+}
+
+func TestUndoJSONPatchKeepsOtherChanges(t *testing.T) {
+	doc := mustDecodeJSON(t, `{"Protocol": "tcp", "Upstreams": ["a"]}`)
+	operations, err := decodeJSONPatch([]byte(`[{"op": "replace", "path": "/Protocol", "value": "http"}, {"op": "add", "path": "/Upstreams/-", "value": "b"}]`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patched, inverse, err := applyJSONPatch(doc, operations)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another writer changed the protocol since
+	patched.(map[string]any)["Protocol"] = "grpc"
+
+	undone := undoJSONPatch(patched, inverse)
+
+	if expected := mustDecodeJSON(t, `{"Protocol": "grpc", "Upstreams": ["a"]}`); !jsonEqual(undone, expected) {
+		t.Errorf("expected only the unmodified values to be restored, got %s", encodeJSONValue(undone))
+	}
+}
+
+func TestUndoJSONPatchKeepsMembersSetAgain(t *testing.T) {
+	doc := mustDecodeJSON(t, `{"Protocol": "tcp", "Meta": {"team": "a"}, "Upstreams": ["a", "b"]}`)
+	operations, err := decodeJSONPatch([]byte(`[{"op": "remove", "path": "/Protocol"}, {"op": "remove", "path": "/Meta/team"}, {"op": "remove", "path": "/Upstreams/0"}]`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patched, inverse, err := applyJSONPatch(doc, operations)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another writer set the protocol again since
+	patched.(map[string]any)["Protocol"] = "grpc"
+
+	undone := undoJSONPatch(patched, inverse)
+
+	if expected := mustDecodeJSON(t, `{"Protocol": "grpc", "Meta": {"team": "a"}, "Upstreams": ["a", "b"]}`); !jsonEqual(undone, expected) {
+		t.Errorf("expected only the members still absent to be restored, got %s", encodeJSONValue(undone))
+	}
+}
@@ -7,14 +7,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
-	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	api "github.com/hashicorp/consul/api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -35,10 +35,12 @@ type UtilsProvider struct {
 
 // UtilsProviderModel describes the provider data model.
 type UtilsProviderModel struct {
-	ConsulClusterAddress types.String `tfsdk:"consul_cluster_address"`
-	ConsulClusterScheme  types.String `tfsdk:"consul_cluster_scheme"`
-	ConsulToken          types.String `tfsdk:"consul_token"`
-	AclAuthMethod        types.String `tfsdk:"acl_auth_method"`
+	ConsulClusterModel
+	Clusters        []ConsulNamedClusterModel `tfsdk:"cluster"`
+	RequestTimeout  types.String              `tfsdk:"request_timeout"`
+	MaxRetries      types.Int64               `tfsdk:"max_retries"`
+	RetryBackoffMin types.String              `tfsdk:"retry_backoff_min"`
+	RetryBackoffMax types.String              `tfsdk:"retry_backoff_max"`
 }
 
 func IsValidUUID(u string) bool {
@@ -46,77 +48,97 @@ func IsValidUUID(u string) bool {
 	return err == nil
 }
 
-func loginToConsul(httpClient *http.Client, providerModel UtilsProviderModel, diagnostics *diag.Diagnostics) (*api.Client, error) {
-	consulAddress := "127.0.0.1:8500"
-	consulScheme := "http"
-	var consulToken string
+func loginToConsul(httpClient *http.Client, clusterModel ConsulClusterModel, diagnostics *diag.Diagnostics) (*api.Client, error) {
+	endpoint, err := resolveConsulAddress(clusterModel)
 
-	consulHttpAddrEnv := os.Getenv("CONSUL_HTTP_ADDR")
+	if err != nil {
+		diagnostics.AddError("Invalid Consul Address", err.Error())
+		return nil, err
+	}
 
-	if providerModel.ConsulClusterAddress.IsNull() {
-		if consulHttpAddrEnv != "" && strings.Contains(consulHttpAddrEnv, "://") {
-			consulAddress = strings.Split(consulHttpAddrEnv, "://")[1]
-		} else if consulHttpAddrEnv != "" {
-			consulAddress = consulHttpAddrEnv
-		}
-	} else {
-		consulAddress = providerModel.ConsulClusterAddress.ValueString()
+	// Agents listening on a unix socket are reached through a dedicated transport
+	if endpoint.Scheme == "unix" {
+		httpClient = withUnixSocket(httpClient, endpoint.Host)
+		endpoint = consulEndpoint{Scheme: "http", Host: unixSocketHost}
 	}
 
-	if providerModel.ConsulClusterScheme.IsNull() {
-		if consulHttpAddrEnv != "" && strings.Contains(consulHttpAddrEnv, "://") {
-			consulScheme = strings.Split(consulHttpAddrEnv, "://")[0]
-		}
-	} else {
-		consulScheme = providerModel.ConsulClusterScheme.ValueString()
+	tlsConfig, err := resolveTLSConfig(clusterModel)
+
+	if err == nil && tlsConfig != nil {
+		httpClient, err = withTLSConfig(httpClient, tlsConfig)
 	}
 
-	if providerModel.ConsulToken.IsNull() {
-		if os.Getenv("CONSUL_HTTP_TOKEN") != "" {
-			consulToken = os.Getenv("CONSUL_HTTP_TOKEN")
-		} else {
-			diagnostics.AddError("Client Error", "Unable to locate initial consul token")
-		}
-	} else {
-		consulToken = providerModel.ConsulToken.ValueString()
+	if err != nil {
+		diagnostics.AddError("Invalid TLS Configuration", fmt.Sprintf("Unable to configure TLS for the consul cluster, got error: %s", err))
+		return nil, err
 	}
 
 	consulConfig := api.Config{
-		Address:    consulAddress,
-		Scheme:     consulScheme,
+		Address:    endpoint.Host,
+		Scheme:     endpoint.Scheme,
+		PathPrefix: endpoint.PathPrefix,
 		HttpClient: httpClient,
+		HttpAuth:   resolveHttpAuth(clusterModel),
+	}
+
+	headers := consulHeaders(clusterModel)
+
+	// Clusters without ACLs accept requests without a token
+	if !isACLEnabled(clusterModel) {
+		client, err := newConsulClient(&consulConfig, headers)
+
+		if err != nil {
+			diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create consul client, got error: %s", err))
+			return nil, err
+		}
+
+		return client, nil
 	}
 
-	client, err := api.NewClient(&consulConfig)
+	consulToken, err := resolveConsulToken(clusterModel)
 
 	if err != nil {
-		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create consul client, got error: %s", err))
+		addAuthenticationError(diagnostics, err)
 		return nil, err
 	}
 
-	var aclToken string
+	client, err := newConsulClient(&consulConfig, headers)
+
+	if err != nil {
+		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create consul client, got error: %s", err))
+		return nil, err
+	}
 
 	if IsValidUUID(consulToken) {
-		aclToken = consulToken
-	} else if !providerModel.AclAuthMethod.IsNull() {
-		token, _, err := client.ACL().Login(&api.ACLLoginParams{
-			AuthMethod:  providerModel.AclAuthMethod.ValueString(),
-			BearerToken: consulToken,
-		}, nil)
+		consulConfig.Token = consulToken
+	} else {
+		err = validateJWT(consulToken, time.Now())
+
+		if err == nil && clusterModel.AclAuthMethod.IsNull() {
+			err = &MissingCredentialsError{
+				Reason: "the consul token is a JWT, which can only be used to log in to an auth method, but acl_auth_method is not set",
+			}
+		}
 
 		if err != nil {
-			diagnostics.AddError("Client Error", fmt.Sprintf("Unable to authenticate to consul, got error: %s", err))
+			addAuthenticationError(diagnostics, err)
 			return nil, err
 		}
 
-		aclToken = token.SecretID
-	} else {
-		diagnostics.AddError("Client Error", "Cannot authenticate using JWT token without acl auth method")
-	}
+		tokenSource := newConsulLoginTokenSource(client, clusterModel, consulToken)
 
-	consulConfig.Token = aclToken
+		_, err = tokenSource.Token(context.Background())
 
-	client, err = api.NewClient(&consulConfig)
+		if err != nil {
+			addAuthenticationError(diagnostics, err)
+			return nil, err
+		}
+
+		// The token is set on each request, so it can be renewed when it expires
+		consulConfig.HttpClient = withConsulTokenSource(httpClient, tokenSource)
+	}
+
+	client, err = newConsulClient(&consulConfig, headers)
 
 	if err != nil {
 		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create consul client, got error: %s", err))
@@ -132,25 +154,51 @@ func (p *UtilsProvider) Metadata(ctx context.Context, req provider.MetadataReque
 }
 
 func (p *UtilsProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	clusterAttributes := consulClusterAttributes(func(variable string) string {
+		return " Can also be set with the `" + variable + "` environment variable."
+	})
+
+	namedClusterAttributes := consulClusterAttributes(func(variable string) string {
+		return ""
+	})
+
+	namedClusterAttributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "Name of the cluster, set in the `cluster` attribute of the resources and data sources managing it.",
+		Required:            true,
+	}
+
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "The top-level attributes configure the default consul cluster. Resources and data sources can manage other clusters, each configured by a `cluster` block, by setting their `cluster` attribute to its name.",
 		Attributes: map[string]schema.Attribute{
-			"consul_cluster_address": schema.StringAttribute{
-				MarkdownDescription: "The address of the Consul cluster.",
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single request to the consul cluster, as a duration such as `30s`. `0s` disables the timeout. Defaults to `" + defaultRequestTimeout + "`.",
 				Optional:            true,
 			},
-			"consul_cluster_scheme": schema.StringAttribute{
-				MarkdownDescription: "The scheme used to connect to the consul cluster. Can be http or https.",
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a request failing with a network error, a 5xx or a 429 status is retried, and a conflicting CAS write of a config entry is attempted again. Only reads are retried on errors, writes of config entries being retried by their CAS loop. Defaults to %d.", defaultMaxRetries),
 				Optional:            true,
 			},
-			"consul_token": schema.StringAttribute{
-				MarkdownDescription: "The token used to authenticate to the consul cluster. Can be a JWT formatted token or a UUIDv4 secret ID",
+			"retry_backoff_min": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry, doubled on each following retry. Defaults to `" + defaultRetryBackoffMin + "`.",
 				Optional:            true,
 			},
-			"acl_auth_method": schema.StringAttribute{
-				MarkdownDescription: "Auth method used when the token is JWT encoded. Not needed if the token is a UUIDv4 secret ID.",
+			"retry_backoff_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum delay between two retries. Defaults to `" + defaultRetryBackoffMax + "`.",
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"cluster": schema.ListNestedBlock{
+				MarkdownDescription: "Additional consul cluster, with the same attributes as the top-level cluster. Unlike the top-level cluster, its attributes are not read from the `CONSUL_*` environment variables.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: namedClusterAttributes,
+				},
+			},
+		},
+	}
+
+	for name, attribute := range clusterAttributes {
+		resp.Schema.Attributes[name] = attribute
 	}
 }
 
@@ -163,13 +211,40 @@ func (p *UtilsProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	// Example client configuration for data sources and resources
-	resp.DataSourceData = func(diagnostics *diag.Diagnostics) (*api.Client, error) {
-		return loginToConsul(http.DefaultClient, data, diagnostics)
+	retryPolicy, err := newRetryPolicy(data)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Retry Policy", err.Error())
+		return
 	}
-	resp.ResourceData = func(diagnostics *diag.Diagnostics) (*api.Client, error) {
-		return loginToConsul(http.DefaultClient, data, diagnostics)
+
+	clusters := make(map[string]ConsulClusterModel, len(data.Clusters))
+
+	for idx, cluster := range data.Clusters {
+		name := cluster.Name.ValueString()
+
+		if name == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("cluster").AtListIndex(idx).AtName("name"), "Invalid Cluster Name", "The name of a cluster cannot be empty, the top-level cluster is used when the cluster attribute of a resource is not set.")
+			continue
+		}
+
+		if _, ok := clusters[name]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("cluster").AtListIndex(idx).AtName("name"), "Duplicate Cluster Name", fmt.Sprintf("Another cluster is already named %q.", name))
+			continue
+		}
+
+		cluster.IgnoreEnvironment = true
+		clusters[name] = cluster.ConsulClusterModel
 	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData := newUtilsProviderData(newRetryingHTTPClient(ctx, retryPolicy), retryPolicy, data.ConsulClusterModel, clusters)
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *UtilsProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -177,15 +252,50 @@ func (p *UtilsProvider) Resources(ctx context.Context) []func() resource.Resourc
 		NewConsulExportedServiceResource,
 		NewConsulSingleIntentionResource,
 		NewConsulKeyResource,
+		NewConsulServiceDefaultsFieldResource,
+		NewConsulServiceUpstreamOverrideResource,
+		NewConsulServiceRouterRouteResource,
+		NewConsulServiceSplitterSplitResource,
+		NewConsulServiceResolverFailoverResource,
+		NewConsulServiceResolverSubsetResource,
+		NewConsulIngressGatewayServiceResource,
+		NewConsulTerminatingGatewayServiceResource,
+		NewConsulAPIGatewayRouteAttachmentResource,
+		NewConsulHTTPRouteRuleResource,
+		NewConsulConfigEntryPatchResource,
+		NewConsulACLPolicyRuleResource,
+		NewConsulACLRolePolicyAttachmentResource,
+		NewConsulACLRoleServiceIdentityResource,
+		NewConsulACLAuthMethodResource,
+		NewConsulACLBindingRuleResource,
 	}
 }
 
 func (p *UtilsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewConsulPeeringsDataSource,
+		NewConsulExportedServicesDataSource,
+		NewConsulServiceIntentionsDataSource,
+		NewConsulIntentionCheckDataSource,
+	}
 }
 
 func (p *UtilsProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewConsulServiceNameFunction,
+		NewIntentionIdFunction,
+		NewParseIntentionIdFunction,
+		NewJSONMergePatchFunction,
+		NewJSONPatchFunction,
+		NewDeepMergeFunction,
+		NewTOMLDecodeFunction,
+		NewTOMLEncodeFunction,
+		NewINIDecodeFunction,
+		NewINIEncodeFunction,
+		NewDotenvDecodeFunction,
+		NewDotenvEncodeFunction,
+		NewYAMLEncodeFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -4,9 +4,17 @@
 package provider
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -23,3 +31,151 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccPreCheckACL skips the test when the consul cluster runs without
+// ACLs, as the ACL objects it manages cannot be created there.
+func testAccPreCheckACL(t *testing.T) {
+	testAccPreCheck(t)
+
+	if !isACLEnabled(ConsulClusterModel{}) {
+		t.Skip("ACLs are disabled on the consul cluster, set CONSUL_ACL_ENABLED to run this test")
+	}
+}
+
+// testRunFunction runs the provider function with the arguments, returning
+// its result or its error.
+func testRunFunction(t *testing.T, f function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	result, funcErr := definition.Definition.Return.NewResultData(ctx)
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	resp := &function.RunResponse{Result: result}
+
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func TestResolveConsulToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		clusterModel ConsulClusterModel
+		env          map[string]string
+		expected     string
+		expectError  bool
+	}{
+		"attribute": {
+			clusterModel: ConsulClusterModel{
+				ConsulToken:     types.StringValue("attribute-token"),
+				ConsulTokenFile: types.StringValue(tokenFile),
+			},
+			env:      map[string]string{"CONSUL_HTTP_TOKEN": "env-token"},
+			expected: "attribute-token",
+		},
+		"file attribute": {
+			clusterModel: ConsulClusterModel{
+				ConsulTokenFile: types.StringValue(tokenFile),
+			},
+			env:      map[string]string{"CONSUL_HTTP_TOKEN": "env-token"},
+			expected: "file-token",
+		},
+		"command attribute": {
+			clusterModel: ConsulClusterModel{
+				ConsulTokenCommand: []types.String{types.StringValue("echo"), types.StringValue("command-token")},
+			},
+			expected: "command-token",
+		},
+		"failing command": {
+			clusterModel: ConsulClusterModel{
+				ConsulTokenCommand: []types.String{types.StringValue("false")},
+			},
+			expectError: true,
+		},
+		"env": {
+			env: map[string]string{
+				"CONSUL_HTTP_TOKEN":      "env-token",
+				"CONSUL_HTTP_TOKEN_FILE": tokenFile,
+			},
+			expected: "env-token",
+		},
+		"file env": {
+			env:      map[string]string{"CONSUL_HTTP_TOKEN_FILE": tokenFile},
+			expected: "file-token",
+		},
+		"missing file": {
+			env:         map[string]string{"CONSUL_HTTP_TOKEN_FILE": filepath.Join(t.TempDir(), "missing")},
+			expectError: true,
+		},
+		"none": {
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("CONSUL_HTTP_TOKEN", "")
+			t.Setenv("CONSUL_HTTP_TOKEN_FILE", "")
+
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			token, err := resolveConsulToken(testCase.clusterModel)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got token %q", token)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if token != testCase.expected {
+				t.Errorf("expected token %q, got %q", testCase.expected, token)
+			}
+		})
+	}
+}
+
+func TestRunConsulTokenCommand(t *testing.T) {
+	_, err := runConsulTokenCommand([]types.String{types.StringValue("sh"), types.StringValue("-c"), types.StringValue("echo denied >&2; exit 1")})
+
+	if err == nil || !strings.Contains(err.Error(), "stderr: denied") {
+		t.Errorf("expected the error to contain the standard error of the command, got %v", err)
+	}
+
+	timeout := consulTokenCommandTimeout
+	consulTokenCommandTimeout = 50 * time.Millisecond
+	defer func() { consulTokenCommandTimeout = timeout }()
+
+	start := time.Now()
+
+	_, err = runConsulTokenCommand([]types.String{types.StringValue("sleep"), types.StringValue("10")})
+
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected a hanging command to time out, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the command to be killed after the timeout, took %s", elapsed)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &TOMLDecodeFunction{}
+var _ function.Function = &TOMLEncodeFunction{}
+
+func NewTOMLDecodeFunction() function.Function {
+	return &TOMLDecodeFunction{}
+}
+
+func NewTOMLEncodeFunction() function.Function {
+	return &TOMLEncodeFunction{}
+}
+
+// TOMLDecodeFunction defines the function implementation.
+type TOMLDecodeFunction struct{}
+
+// TOMLEncodeFunction defines the function implementation.
+type TOMLEncodeFunction struct{}
+
+func (f *TOMLDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "toml_decode"
+}
+
+func (f *TOMLDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decodes a TOML document",
+		MarkdownDescription: "Decodes a TOML document into an object, the tables being decoded as objects and the arrays as tuples. The dates and the times are decoded as strings in RFC 3339 format.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "doc",
+				MarkdownDescription: "The TOML document to decode",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *TOMLDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var doc string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &doc))
+
+	if resp.Error != nil {
+		return
+	}
+
+	var decoded map[string]any
+
+	if _, err := toml.Decode(doc, &decoded); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid TOML document: %s", err))
+		return
+	}
+
+	// An empty document is an empty table
+	if decoded == nil {
+		decoded = map[string]any{}
+	}
+
+	result, err := fromNativeValue(decoded)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to decode the TOML document: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.DynamicValue(result)))
+}
+
+func (f *TOMLEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "toml_encode"
+}
+
+func (f *TOMLEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes an object as a TOML document",
+		MarkdownDescription: "Encodes an object or a map as a TOML document with its keys sorted, the nested objects being encoded as tables and the lists of objects as arrays of tables. The `null` attributes are left out since TOML has no null value, and the whole numbers are encoded as integers.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "value",
+				MarkdownDescription: "The object to encode",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TOMLEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	native, err := toNativeValue(value)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	object, ok := native.(map[string]any)
+
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("expected an object or a map, got %s", value.UnderlyingValue().Type(ctx)))
+		return
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := toml.NewEncoder(&buffer)
+	encoder.Indent = ""
+
+	if err := encoder.Encode(object); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to encode the value in TOML: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, buffer.String()))
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTOMLFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "port" {
+	value = provider::utils::toml_decode("[server]\nport = 8080\n").server.port
+}
+
+output "encoded" {
+	value = provider::utils::toml_encode({ name = "web", server = { port = 8080 } })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("port", "8080"),
+					resource.TestCheckOutput("encoded", "name = \"web\"\n\n[server]\nport = 8080\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestTOMLFunctions(t *testing.T) {
+	doc := `title = "web"
+ratio = 0.5
+enabled = true
+tags = ["a", "b"]
+released = 1979-05-27T07:32:00Z
+day = 1979-05-27
+
+[server]
+port = 8080
+
+[[routes]]
+path = "/api"
+
+[[routes]]
+path = "/admin"
+`
+
+	expected := testObject(map[string]attr.Value{
+		"title":    types.StringValue("web"),
+		"ratio":    types.NumberValue(big.NewFloat(0.5)),
+		"enabled":  types.BoolValue(true),
+		"tags":     testTuple(types.StringValue("a"), types.StringValue("b")),
+		"released": types.StringValue("1979-05-27T07:32:00Z"),
+		"day":      types.StringValue("1979-05-27"),
+		"server":   testObject(map[string]attr.Value{"port": types.NumberValue(big.NewFloat(8080))}),
+		"routes": testTuple(
+			testObject(map[string]attr.Value{"path": types.StringValue("/api")}),
+			testObject(map[string]attr.Value{"path": types.StringValue("/admin")}),
+		),
+	})
+
+	result, funcErr := testRunFunction(t, NewTOMLDecodeFunction(), types.StringValue(doc))
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	decoded := result.(types.Dynamic).UnderlyingValue()
+
+	if !decoded.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, decoded)
+	}
+
+	// The decoded document is encoded with its keys sorted
+	result, funcErr = testRunFunction(t, NewTOMLEncodeFunction(), types.DynamicValue(decoded))
+
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+
+	encoded := `day = "1979-05-27"
+enabled = true
+ratio = 0.5
+released = "1979-05-27T07:32:00Z"
+tags = ["a", "b"]
+title = "web"
+
+[[routes]]
+path = "/api"
+
+[[routes]]
+path = "/admin"
+
+[server]
+port = 8080
+`
+
+	if !result.Equal(types.StringValue(encoded)) {
+		t.Errorf("expected\n%s\ngot\n%s", encoded, result.(types.String).ValueString())
+	}
+
+	result, funcErr = testRunFunction(t, NewTOMLDecodeFunction(), types.StringValue(""))
+
+	if funcErr != nil || !result.(types.Dynamic).UnderlyingValue().Equal(testObject(map[string]attr.Value{})) {
+		t.Errorf("expected an empty document to be an empty object, got %s, %v", result, funcErr)
+	}
+
+	// The null attributes are left out
+	result, funcErr = testRunFunction(t, NewTOMLEncodeFunction(), types.DynamicValue(testObject(map[string]attr.Value{
+		"name": types.StringValue("web"),
+		"peer": types.StringNull(),
+		"port": types.Int64Value(8080),
+	})))
+
+	if expected := "name = \"web\"\nport = 8080\n"; funcErr != nil || !result.Equal(types.StringValue(expected)) {
+		t.Errorf("expected %q, got %s, %v", expected, result, funcErr)
+	}
+
+	if result, funcErr := testRunFunction(t, NewTOMLDecodeFunction(), types.StringValue("name = ")); funcErr == nil {
+		t.Errorf("expected an error for an invalid document, got %s", result)
+	}
+
+	for _, value := range []attr.Value{types.StringValue("web"), testTuple(types.StringValue("web")), testObject(map[string]attr.Value{"a": testTuple(types.StringNull())})} {
+		if result, funcErr := testRunFunction(t, NewTOMLEncodeFunction(), types.DynamicValue(value)); funcErr == nil {
+			t.Errorf("expected an error for %s, got %s", value, result)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &YAMLEncodeFunction{}
+
+func NewYAMLEncodeFunction() function.Function {
+	return &YAMLEncodeFunction{}
+}
+
+// YAMLEncodeFunction defines the function implementation.
+type YAMLEncodeFunction struct{}
+
+// yamlNode returns the YAML node of a value returned by toNativeValue,
+// sorting the keys of the mappings.
+func yamlNode(value any) (*yaml.Node, error) {
+	switch value := value.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}, nil
+	case int64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(value, 10)}, nil
+	case float64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(value, 'g', -1, 64)}, nil
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		for _, key := range keys {
+			child, err := yamlNode(value[key])
+
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		}
+
+		return node, nil
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+		for _, element := range value {
+			child, err := yamlNode(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, child)
+		}
+
+		return node, nil
+	}
+
+	return nil, fmt.Errorf("unexpected value %v of type %T", value, value)
+}
+
+func (f *YAMLEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "yaml_encode"
+}
+
+func (f *YAMLEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes a value as a deterministic YAML document",
+		MarkdownDescription: "Encodes a value as a YAML document, like `yamlencode`, but with the keys of the objects and the maps sorted, an indentation of two spaces, and the whole numbers encoded as integers, so that the same value always produces the same document.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "value",
+				MarkdownDescription: "The value to encode",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *YAMLEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	native, err := toNativeValue(value)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	node, err := yamlNode(native)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(node); err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to encode the value in YAML: %s", err))
+		return
+	}
+
+	if err := encoder.Close(); err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to encode the value in YAML: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, buffer.String()))
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccYAMLEncodeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::utils::yaml_encode({ name = "web", ports = [8080], meta = { team = "platform" } })
+}
+`,
+				Check: resource.TestCheckOutput("test", "meta:\n  team: platform\nname: web\nports:\n  - 8080\n"),
+			},
+		},
+	})
+}
+
+func TestYAMLEncodeFunction(t *testing.T) {
+	testCases := map[string]struct {
+		value    attr.Value
+		expected string
+	}{
+		"sorted keys": {
+			value: testObject(map[string]attr.Value{
+				"zone":  types.StringValue("a"),
+				"name":  types.StringValue("web"),
+				"alias": types.StringValue("w"),
+			}),
+			expected: "alias: w\nname: web\nzone: a\n",
+		},
+		"nested": {
+			value: testObject(map[string]attr.Value{
+				"routes": testTuple(
+					testObject(map[string]attr.Value{"path": types.StringValue("/api"), "retries": types.NumberValue(big.NewFloat(3))}),
+				),
+				"meta": types.MapValueMust(types.StringType, map[string]attr.Value{"b": types.StringValue("2"), "a": types.StringValue("1")}),
+				"tags": types.SetValueMust(types.StringType, []attr.Value{types.StringValue("x")}),
+				"none": types.ListValueMust(types.StringType, []attr.Value{}),
+			}),
+			expected: "meta:\n  a: \"1\"\n  b: \"2\"\nnone: []\nroutes:\n  - path: /api\n    retries: 3\ntags:\n  - x\n",
+		},
+		"ambiguous strings": {
+			value: testTuple(
+				types.StringValue("true"),
+				types.StringValue("null"),
+				types.StringValue("0x10"),
+				types.StringValue("1.5"),
+				types.StringValue(""),
+				types.StringValue("a: b"),
+			),
+			expected: "- \"true\"\n- \"null\"\n- \"0x10\"\n- \"1.5\"\n- \"\"\n- 'a: b'\n",
+		},
+		"scalars": {
+			value: testTuple(
+				types.NumberValue(big.NewFloat(1.5)),
+				types.NumberValue(big.NewFloat(-2)),
+				types.BoolValue(false),
+				types.StringNull(),
+			),
+			expected: "- 1.5\n- -2\n- false\n- null\n",
+		},
+		"multiline string": {
+			value:    testObject(map[string]attr.Value{"script": types.StringValue("echo a\necho b\n")}),
+			expected: "script: |\n  echo a\n  echo b\n",
+		},
+		"null": {
+			value:    types.DynamicNull(),
+			expected: "null\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			value := testCase.value
+
+			if _, ok := value.(types.Dynamic); !ok {
+				value = types.DynamicValue(value)
+			}
+
+			result, funcErr := testRunFunction(t, NewYAMLEncodeFunction(), value)
+
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+
+			if !result.Equal(types.StringValue(testCase.expected)) {
+				t.Errorf("expected\n%s\ngot\n%s", testCase.expected, result.(types.String).ValueString())
+			}
+		})
+	}
+}